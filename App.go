@@ -19,13 +19,18 @@ package main
 
 import (
 	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
-	termbox "github.com/nsf/termbox-go"
 	"github.com/stuartthompson/dailyvocab/app"
 	"github.com/stuartthompson/dailyvocab/configuration"
 	"github.com/stuartthompson/dailyvocab/io"
 	"github.com/stuartthompson/dailyvocab/io/screen"
+	"github.com/stuartthompson/dailyvocab/progress"
 	"github.com/stuartthompson/dailyvocab/screens"
+	"github.com/stuartthompson/dailyvocab/transliteration"
 )
 
 // Screen ...
@@ -38,36 +43,71 @@ const (
 	WordListScreen
 	ConfigScreen
 	AboutScreen
+	WordDetailScreen
+	ThemeEditorScreen
+	StatsScreen
+	ProfilesScreen
+	QuizScreen
 )
 
 // configFileName ...
 // The name of the application configuration file.
 const configFileName = ".dailyvocab"
 
+// tickInterval ...
+// Time between clock ticks, after which the screen is rendered again (so that time-based status stays current).
+const tickInterval = time.Minute
+
+// minRolloverDelay ...
+// The shortest time to wait before checking for a new study day (so that a start time in the past cannot make
+// the check repeat without pause).
+const minRolloverDelay = time.Second
+
+// bottomBarHeight ...
+// Height of the bottom bar (including borders).
+const bottomBarHeight = 7
+
 // App ...
 // Encapsulates main application logic.
 type App struct {
-	isRunning       bool
-	eventListener   *io.EventListener
-	configuration   *configuration.AppConfig
-	vocabulary      *app.Vocabulary
-	currentScreen   Screen
-	dailyWordScreen *screens.DailyWordScreen
-	wordListScreen  *screens.WordListScreen
-	configScreen    *screens.ConfigScreen
-	aboutScreen     *screens.AboutScreen
-	bottomBar       *screens.BottomBarComponent
+	isRunning        bool
+	eventListener    *io.EventListener
+	configuration    *configuration.AppConfig
+	vocabulary       *app.Vocabulary
+	wordListFileName string                 // Path of the word list file
+	now              func() time.Time       // Gets the current time
+	getenv           func(string) string    // Gets an environment variable
+	runCommands      func([][]string) error // Runs commands (each a name and arguments) one after another
+	currentScreen    Screen
+	studyDay         time.Time // The study day whose word is shown on the daily word screen
+	theme            *screen.Theme
+	mainViewport     *screen.Viewport
+	bottomViewport   *screen.Viewport
+	dailyWordScreen  *screens.DailyWordScreen
+	wordListScreen   *screens.WordListScreen
+	wordDetailScreen *screens.WordDetailScreen
+	configScreen     *screens.ConfigScreen
+	aboutScreen      *screens.AboutScreen
+	themeEditor      *screens.ThemeEditorScreen
+	statsScreen      *screens.StatsScreen
+	profilesScreen   *screens.ProfilesScreen
+	quizScreen       *screens.QuizScreen
+	bottomBar        *screens.BottomBarComponent
 }
 
 // NewApp ...
 // Initializes a new application instance.
 func NewApp() *App {
 	app := &App{
-		isRunning:     true,
-		configuration: &configuration.AppConfig{},
-		vocabulary:    &app.Vocabulary{},
+		isRunning:        true,
+		configuration:    &configuration.AppConfig{},
+		vocabulary:       &app.Vocabulary{},
+		wordListFileName: app.WordListFileName,
+		now:              time.Now,
+		getenv:           os.Getenv,
+		runCommands:      runCommands,
 	}
-	app.eventListener = io.NewEventListener(app.Render)
+	app.eventListener = io.NewEventListener(app.onResize)
 
 	return app
 }
@@ -75,54 +115,151 @@ func NewApp() *App {
 // Run ...
 // Runs the application.
 func (a *App) Run() {
-	// Initialize termbox
-	err := termbox.Init()
+	// Initialize rendering backend
+	err := io.Init()
 	if err != nil {
 		panic(err)
 	}
-	termbox.SetOutputMode(termbox.Output256)
-	defer termbox.Close()
+	defer io.Close()
+
+	// Prepare the application
+	err = a.start()
+	if err != nil {
+		return
+	}
 
+	// Start main app loop
+	for a.isRunning {
+		a.step()
+	}
+	a.eventListener.Stop()
+}
+
+// start ...
+// Reads configuration and vocabulary, initializes screens and renders the first frame.
+func (a *App) start() error {
 	// Read configuration
-	err = a.configuration.ReadConfiguration()
+	err := a.configuration.ReadConfiguration()
 	if err != nil {
 		log.Print("Unable to read configuration. Exiting.")
-		return
+		return err
 	}
 
+	// Prepare right-to-left text unless the terminal does it
+	io.ConfigureBidi(!a.configuration.TerminalBidi, !a.configuration.TerminalBidi && !a.configuration.TerminalShapesArabic)
+
+	// Select color mode and load theme
+	io.SetColorMode(a.colorMode())
+	a.theme = a.loadTheme()
+	io.SetDefaultColors(a.theme.Color(screen.RoleText), 0)
+
 	// Read vocabulary
-	err = a.vocabulary.Load()
+	err = a.vocabulary.LoadFile(a.wordListFileName)
 	if err != nil {
 		log.Print("Unable to read vocabulary from word list file. Exiting.")
-		return
+		return err
 	}
+	transliteration.Backfill(a.vocabulary.Words)
 
 	// Initialize canvas
-	width, height := io.GetWindowSize()
-
-	// TODO: Use flex-box logic to size canvases
-	bottomBarHeight := 7 // Height including borders
-	mainViewport := screen.NewViewport(0, 0, width, height-bottomBarHeight)
-	bottomViewport := screen.NewViewport(0, height-bottomBarHeight, width, bottomBarHeight)
+	a.mainViewport = screen.NewViewport(0, 0, 0, 0)
+	a.bottomViewport = screen.NewViewport(0, 0, 0, 0)
+	a.layout()
 
 	// Initialize screens
-	a.wordListScreen = screens.NewWordListScreen(a.configuration, a.vocabulary, mainViewport)
-	a.configScreen = screens.NewConfigScreen(a.configuration, mainViewport)
-	a.dailyWordScreen = screens.NewDailyWordScreen(a.configuration, mainViewport)
-	a.aboutScreen = screens.NewAboutScreen(a.configuration, mainViewport)
-	a.bottomBar = screens.NewBottomBarComponent(a.configuration, bottomViewport)
+	a.wordListScreen = screens.NewWordListScreen(a.configuration, a.vocabulary, a.mainViewport, a.theme)
+	a.wordDetailScreen = screens.NewWordDetailScreen(a.configuration, a.vocabulary, a.mainViewport, a.theme)
+	a.configScreen = screens.NewConfigScreen(a.configuration, a.mainViewport, a.theme)
+	a.dailyWordScreen = screens.NewDailyWordScreen(a.configuration, a.vocabulary, a.mainViewport, a.theme)
+	a.aboutScreen = screens.NewAboutScreen(a.configuration, a.mainViewport, a.theme)
+	a.themeEditor = screens.NewThemeEditorScreen(a.configuration, a.mainViewport, a.theme)
+	a.statsScreen = screens.NewStatsScreen(a.configuration, a.vocabulary, a.mainViewport, a.theme, a.now)
+	a.profilesScreen = screens.NewProfilesScreen(a.configuration, a.mainViewport, a.theme)
+	a.quizScreen = screens.NewQuizScreen(a.configuration, a.vocabulary, a.mainViewport, a.theme, a.now)
+	a.bottomBar = screens.NewBottomBarComponent(a.configuration, a.bottomViewport, a.theme, a.now)
 
-	// Register keypress handlers
+	// Register keypress handlers and timers
 	a.registerKeypressHandlers()
+	a.eventListener.SetClock(a.now)
+	a.eventListener.Every(tickInterval, a.onTick)
+
+	// Show today's word, moving on to the next word when the day rolls over
+	a.studyDay = a.configuration.StudyDay(a.now())
+	a.dailyWordScreen.SetDay(a.studyDay)
+	a.scheduleDayRollover()
 
 	// Render screen (initially)
 	a.Render()
 
-	// Start main app loop
-	for a.isRunning {
-		a.eventListener.WaitForEvent()
-		a.Render()
+	return nil
+}
+
+// colorMode ...
+// Gets the configured color mode, detecting it from the environment unless it is set explicitly.
+func (a *App) colorMode() io.ColorMode {
+	name := a.configuration.ColorMode
+	if name == "" || name == "auto" {
+		return io.DetectColorMode(a.getenv)
 	}
+	mode, err := io.ParseColorMode(name)
+	if err != nil {
+		log.Print("Invalid color mode. Error: ", err)
+		return io.DetectColorMode(a.getenv)
+	}
+
+	return mode
+}
+
+// loadTheme ...
+// Gets the configured theme, which is either built in or defined in configuration.
+// Falls back to the dark theme if the configured theme is missing or invalid.
+func (a *App) loadTheme() *screen.Theme {
+	name := a.configuration.Theme
+	if name == "" {
+		return screen.DarkTheme.Copy()
+	}
+	if theme := screen.GetBuiltInTheme(name); theme != nil {
+		return theme.Copy()
+	}
+
+	// Build user-defined theme on top of its base theme
+	themeConfig, ok := a.configuration.Themes[name]
+	if !ok {
+		log.Print("Unknown theme: ", name)
+		return screen.DarkTheme.Copy()
+	}
+	base := screen.DarkTheme
+	if themeConfig.Base != "" {
+		base = screen.GetBuiltInTheme(themeConfig.Base)
+		if base == nil {
+			log.Print("Unknown base theme: ", themeConfig.Base)
+			return screen.DarkTheme.Copy()
+		}
+	}
+	theme, err := base.Derive(name, themeConfig.Colors, themeConfig.Accents)
+	if err != nil {
+		log.Print("Invalid theme. Error: ", err)
+		return screen.DarkTheme.Copy()
+	}
+
+	return theme
+}
+
+// step ...
+// Waits for and handles a single event (a key press, resize, timer or posted callback), then renders.
+func (a *App) step() {
+	a.eventListener.WaitForEvent()
+	a.Render()
+}
+
+// layout ...
+// Sizes the viewports to fit the window.
+func (a *App) layout() {
+	width, height := io.GetWindowSize()
+
+	// TODO: Use flex-box logic to size canvases
+	a.mainViewport.MoveAndResize(0, 0, width, height-bottomBarHeight)
+	a.bottomViewport.MoveAndResize(0, height-bottomBarHeight, width, bottomBarHeight)
 }
 
 // Render ...
@@ -138,14 +275,74 @@ func (a *App) Render() {
 		a.configScreen.Render()
 	case AboutScreen:
 		a.aboutScreen.Render()
+	case WordDetailScreen:
+		a.wordDetailScreen.Render()
+	case ThemeEditorScreen:
+		a.themeEditor.Render()
+	case StatsScreen:
+		a.statsScreen.Render()
+	case ProfilesScreen:
+		a.profilesScreen.Render()
+	case QuizScreen:
+		a.quizScreen.Render()
 	}
 
 	// Render bottom bar
+	a.bottomBar.SetKeyHints(a.screenKeyHints(), globalKeyHints)
 	a.bottomBar.Render()
 
 	io.Flush()
 }
 
+// globalKeyHints ...
+// Keys shown in the bottom bar on every screen.
+var globalKeyHints = []screens.KeyHint{
+	{Key: "w", Description: "word"},
+	{Key: "l", Description: "list"},
+	{Key: "p", Description: "stats"},
+	{Key: "u", Description: "profile"},
+	{Key: "t", Description: "theme"},
+	{Key: "c", Description: "config"},
+	{Key: "?", Description: "about"},
+	{Key: "q", Description: "quit"},
+}
+
+// screenKeyHints ...
+// Gets the keys shown in the bottom bar for the current screen.
+func (a *App) screenKeyHints() []screens.KeyHint {
+	switch a.currentScreen {
+	case WordListScreen:
+		if a.wordListScreen.IsSearching() {
+			return []screens.KeyHint{{Key: "Enter", Description: "filter"}, {Key: "Esc", Description: "clear"}}
+		}
+		return []screens.KeyHint{{Key: "↑↓", Description: "select"}, {Key: "Enter", Description: "open"}, {Key: "/", Description: "filter"}, {Key: "z", Description: "quiz"}, {Key: "g", Description: "grammar"}, {Key: "b", Description: "cloze"}}
+	case DailyWordScreen:
+		return []screens.KeyHint{{Key: "m", Description: "mark learned"}, {Key: "r", Description: "reviewed"}, {Key: "a", Description: "pronounce"}, {Key: "z", Description: "quiz"}, {Key: "g", Description: "grammar"}, {Key: "b", Description: "cloze"}}
+	case WordDetailScreen:
+		return []screens.KeyHint{{Key: "m", Description: "mark learned"}, {Key: "r", Description: "reviewed"}, {Key: "x", Description: "reset"}, {Key: "a", Description: "pronounce"}, {Key: "Esc", Description: "back"}}
+	case ThemeEditorScreen:
+		if a.themeEditor.IsNaming() {
+			return []screens.KeyHint{{Key: "Enter", Description: "save"}, {Key: "Esc", Description: "cancel"}}
+		}
+		return []screens.KeyHint{{Key: "↑↓←→", Description: "color"}, {Key: "[ ]", Description: "role"}, {Key: "s", Description: "save"}, {Key: "Esc", Description: "back"}}
+	case ProfilesScreen:
+		if a.profilesScreen.IsNaming() {
+			return []screens.KeyHint{{Key: "Enter", Description: "create"}, {Key: "Esc", Description: "cancel"}}
+		}
+		return []screens.KeyHint{{Key: "↑↓", Description: "select"}, {Key: "Enter", Description: "switch"}, {Key: "n", Description: "new"}}
+	case QuizScreen:
+		if a.quizScreen.IsAnswering() {
+			return []screens.KeyHint{{Key: "Enter", Description: "check"}, {Key: "Esc", Description: "stop"}}
+		}
+		if a.quizScreen.Current() != nil {
+			return []screens.KeyHint{{Key: "Enter", Description: "next"}, {Key: "Esc", Description: "stop"}}
+		}
+		return []screens.KeyHint{{Key: "Esc", Description: "back"}}
+	}
+
+	return nil
+}
+
 // registerKeypressHandlers ...
 // Registers the key press handlers.
 func (a *App) registerKeypressHandlers() {
@@ -154,7 +351,32 @@ func (a *App) registerKeypressHandlers() {
 	a.eventListener.RegisterKeypressHandler('w', a.showDailyWordScreen)
 	a.eventListener.RegisterKeypressHandler('l', a.showWordListScreen)
 	a.eventListener.RegisterKeypressHandler('c', a.showConfigScreen)
+	a.eventListener.RegisterKeypressHandler('t', a.showThemeEditorScreen)
+	a.eventListener.RegisterKeypressHandler('p', a.showStatsScreen)
+	a.eventListener.RegisterKeypressHandler('u', a.showProfilesScreen)
 	a.eventListener.RegisterKeypressHandler('q', a.onQuit)
+	a.eventListener.RegisterKeypressHandler('j', a.onSelectNext)
+	a.eventListener.RegisterKeypressHandler('k', a.onSelectPrevious)
+	a.eventListener.RegisterKeyHandler(io.KeyArrowDown, a.onSelectNext)
+	a.eventListener.RegisterKeyHandler(io.KeyArrowUp, a.onSelectPrevious)
+	a.eventListener.RegisterKeyHandler(io.KeyArrowLeft, a.onMoveLeft)
+	a.eventListener.RegisterKeyHandler(io.KeyArrowRight, a.onMoveRight)
+	a.eventListener.RegisterKeyHandler(io.KeyEnter, a.onEnter)
+	a.eventListener.RegisterKeyHandler(io.KeyEsc, a.onBack)
+	a.eventListener.RegisterKeyHandler(io.KeyBackspace, a.onBackspace)
+	a.eventListener.RegisterKeyHandler(io.KeyBackspace2, a.onBackspace)
+	a.eventListener.RegisterKeypressHandler('m', a.onMarkLearned)
+	a.eventListener.RegisterKeypressHandler('r', a.onMarkReviewed)
+	a.eventListener.RegisterKeypressHandler('x', a.onResetWord)
+	a.eventListener.RegisterKeypressHandler('a', a.onPronounce)
+	a.eventListener.RegisterKeypressHandler('[', a.onSelectPreviousRole)
+	a.eventListener.RegisterKeypressHandler(']', a.onSelectNextRole)
+	a.eventListener.RegisterKeypressHandler('s', a.onSaveTheme)
+	a.eventListener.RegisterKeypressHandler('n', a.onNewProfile)
+	a.eventListener.RegisterKeypressHandler('z', a.startQuiz)
+	a.eventListener.RegisterKeypressHandler('g', a.startGrammarQuiz)
+	a.eventListener.RegisterKeypressHandler('b', a.startClozeQuiz)
+	a.eventListener.RegisterKeypressHandler('/', a.onSearch)
 }
 
 func (a *App) showDailyWordScreen() {
@@ -173,6 +395,488 @@ func (a *App) showAboutScreen() {
 	a.currentScreen = AboutScreen
 }
 
+func (a *App) showStatsScreen() {
+	a.statsScreen.SetLeaderboard(a.leaderboard())
+	a.currentScreen = StatsScreen
+}
+
+func (a *App) showProfilesScreen() {
+	a.profilesScreen.SetStandings(a.leaderboard())
+	a.currentScreen = ProfilesScreen
+}
+
+func (a *App) showThemeEditorScreen() {
+	a.themeEditor.Edit(a.theme)
+	a.currentScreen = ThemeEditorScreen
+}
+
+// onSelectNext ...
+// Called when the selection should move down the word list (or the theme editor's color grid).
+func (a *App) onSelectNext() {
+	switch a.currentScreen {
+	case WordListScreen:
+		a.wordListScreen.SelectNext()
+	case ThemeEditorScreen:
+		a.themeEditor.MoveCursor(0, 1)
+	case ProfilesScreen:
+		a.profilesScreen.SelectNext()
+	}
+}
+
+// onSelectPrevious ...
+// Called when the selection should move up the word list (or the theme editor's color grid).
+func (a *App) onSelectPrevious() {
+	switch a.currentScreen {
+	case WordListScreen:
+		a.wordListScreen.SelectPrevious()
+	case ThemeEditorScreen:
+		a.themeEditor.MoveCursor(0, -1)
+	case ProfilesScreen:
+		a.profilesScreen.SelectPrevious()
+	}
+}
+
+// onMoveLeft ...
+// Called when the left arrow key is pressed.
+func (a *App) onMoveLeft() {
+	if a.currentScreen == ThemeEditorScreen {
+		a.themeEditor.MoveCursor(-1, 0)
+	}
+}
+
+// onMoveRight ...
+// Called when the right arrow key is pressed.
+func (a *App) onMoveRight() {
+	if a.currentScreen == ThemeEditorScreen {
+		a.themeEditor.MoveCursor(1, 0)
+	}
+}
+
+// onEnter ...
+// Called when the enter key is pressed. Opens the selected word in the word list, saves the theme
+// being named in the theme editor, switches to the selected (or newly named) profile, or checks the
+// answer to a quiz question (then moves on to the next question).
+func (a *App) onEnter() {
+	switch a.currentScreen {
+	case WordListScreen:
+		if a.wordListScreen.IsSearching() {
+			a.eventListener.ReleaseTextInput()
+			a.wordListScreen.ApplySearch()
+			return
+		}
+		wordID := a.wordListScreen.GetSelectedWordID()
+		a.wordDetailScreen.SetWord(wordID)
+		a.currentScreen = WordDetailScreen
+		if wordID != 0 {
+			a.configuration.RecordView(wordID, a.now())
+			a.writeConfiguration()
+		}
+	case ThemeEditorScreen:
+		if a.themeEditor.IsNaming() {
+			a.saveTheme()
+		}
+	case ProfilesScreen:
+		if !a.profilesScreen.IsNaming() {
+			a.switchProfile(a.profilesScreen.GetSelectedProfile())
+			return
+		}
+		name, err := a.profilesScreen.NewProfileName()
+		if err != nil {
+			return
+		}
+		a.eventListener.ReleaseTextInput()
+		a.switchProfile(name)
+	case QuizScreen:
+		if a.quizScreen.IsAnswering() {
+			a.answerQuestion()
+		} else if a.quizScreen.Current() != nil && a.quizScreen.Next() {
+			a.askQuestion()
+		}
+	}
+}
+
+// onBack ...
+// Called when the user wants to go back (from the word detail screen to the word list, or out of the
+// theme editor or a quiz), to stop naming a theme or profile, or to clear the word list's filter.
+func (a *App) onBack() {
+	switch a.currentScreen {
+	case WordListScreen:
+		if a.wordListScreen.IsSearching() {
+			a.eventListener.ReleaseTextInput()
+		}
+		a.wordListScreen.ClearSearch()
+	case WordDetailScreen:
+		a.currentScreen = WordListScreen
+	case ThemeEditorScreen:
+		if a.themeEditor.IsNaming() {
+			a.themeEditor.CancelNaming()
+			a.eventListener.ReleaseTextInput()
+			return
+		}
+		a.currentScreen = AboutScreen
+	case ProfilesScreen:
+		if a.profilesScreen.IsNaming() {
+			a.profilesScreen.CancelNaming()
+			a.eventListener.ReleaseTextInput()
+		}
+	case QuizScreen:
+		if a.quizScreen.IsAnswering() {
+			a.eventListener.ReleaseTextInput()
+		}
+		a.currentScreen = DailyWordScreen
+	}
+}
+
+// onBackspace ...
+// Called when the backspace key is pressed.
+func (a *App) onBackspace() {
+	if a.currentScreen == ThemeEditorScreen && a.themeEditor.IsNaming() {
+		a.themeEditor.DeleteNameCharacter()
+	}
+	if a.currentScreen == ProfilesScreen && a.profilesScreen.IsNaming() {
+		a.profilesScreen.DeleteNameCharacter()
+	}
+	if a.currentScreen == QuizScreen && a.quizScreen.IsAnswering() {
+		a.quizScreen.DeleteAnswerCharacter()
+	}
+	if a.currentScreen == WordListScreen && a.wordListScreen.IsSearching() {
+		a.wordListScreen.DeleteSearchCharacter()
+	}
+}
+
+// onSelectNextRole ...
+// Called when the theme editor should edit the next role.
+func (a *App) onSelectNextRole() {
+	if a.currentScreen == ThemeEditorScreen {
+		a.themeEditor.SelectNextRole()
+	}
+}
+
+// onSelectPreviousRole ...
+// Called when the theme editor should edit the previous role.
+func (a *App) onSelectPreviousRole() {
+	if a.currentScreen == ThemeEditorScreen {
+		a.themeEditor.SelectPreviousRole()
+	}
+}
+
+// onSaveTheme ...
+// Called when the user wants to save the theme being edited. Captures typed characters for its name
+// until the theme is saved or naming is cancelled.
+func (a *App) onSaveTheme() {
+	if a.currentScreen != ThemeEditorScreen {
+		return
+	}
+	a.themeEditor.StartNaming()
+	a.eventListener.CaptureTextInput(a.themeEditor.TypeNameCharacter)
+}
+
+// saveTheme ...
+// Saves the theme being edited to configuration and applies it.
+func (a *App) saveTheme() {
+	theme, err := a.themeEditor.Save()
+	if err != nil {
+		return
+	}
+	a.eventListener.ReleaseTextInput()
+
+	// Screens share the application theme, so updating it applies the saved theme everywhere
+	*a.theme = *theme
+	io.SetDefaultColors(a.theme.Color(screen.RoleText), 0)
+	if a.writeConfiguration() {
+		a.showMessage("Saved", screen.RoleSuccess)
+	}
+}
+
+// onSearch ...
+// Called when the word list should be filtered (by level, tag, frequency or text), capturing typed characters
+// for the filter.
+func (a *App) onSearch() {
+	if a.currentScreen != WordListScreen || a.wordListScreen.IsSearching() {
+		return
+	}
+	a.wordListScreen.StartSearch()
+	a.eventListener.CaptureTextInput(a.wordListScreen.TypeSearchCharacter)
+}
+
+// onNewProfile ...
+// Called when the user wants to create a profile. Captures typed characters for its name until the
+// profile is created or naming is cancelled.
+func (a *App) onNewProfile() {
+	if a.currentScreen != ProfilesScreen {
+		return
+	}
+	a.profilesScreen.StartNaming()
+	a.eventListener.CaptureTextInput(a.profilesScreen.TypeNameCharacter)
+}
+
+// leaderboard ...
+// Gets the standings of the profiles sharing the installation.
+func (a *App) leaderboard() []progress.Standing {
+	profiles, err := a.configuration.ReadProfiles()
+	if err != nil {
+		log.Print("Unable to read profiles. Error: ", err)
+		profiles = []*configuration.AppConfig{a.configuration}
+	}
+
+	return progress.Leaderboard(profiles, a.now())
+}
+
+// switchProfile ...
+// Saves the current profile, then reads another profile (creating it if it does not exist) and shows
+// its word of the day.
+func (a *App) switchProfile(name string) {
+	if name == "" || name == a.configuration.ProfileName() {
+		return
+	}
+	if !a.writeConfiguration() {
+		return
+	}
+	if err := a.configuration.SwitchProfile(name); err != nil {
+		log.Print("Unable to switch profile. Error: ", err)
+		a.showMessage("Unable to switch profile", screen.RoleError)
+		return
+	}
+
+	// Apply the profile's display settings (screens share the application configuration and theme)
+	io.ConfigureBidi(!a.configuration.TerminalBidi, !a.configuration.TerminalBidi && !a.configuration.TerminalShapesArabic)
+	io.SetColorMode(a.colorMode())
+	*a.theme = *a.loadTheme()
+	io.SetDefaultColors(a.theme.Color(screen.RoleText), 0)
+	io.ClearScreen(0)
+
+	a.studyDay = a.configuration.StudyDay(a.now())
+	a.dailyWordScreen.SetDay(a.studyDay)
+	a.currentScreen = DailyWordScreen
+	a.showMessage("Studying as "+a.configuration.ProfileName(), screen.RoleSuccess)
+}
+
+// startQuiz ...
+// Starts a quiz on the learned words.
+func (a *App) startQuiz() {
+	a.startQuizOfKind(app.VocabularyQuiz, "Learn some words before taking a quiz")
+}
+
+// startGrammarQuiz ...
+// Starts a quiz on the articles and plurals of learned words.
+func (a *App) startGrammarQuiz() {
+	a.startQuizOfKind(app.GrammarQuiz, "Learn some words with articles or plurals before taking a grammar quiz")
+}
+
+// startClozeQuiz ...
+// Starts a quiz that blanks learned words out of their example sentences.
+func (a *App) startClozeQuiz() {
+	a.startQuizOfKind(app.ClozeQuiz, "Learn some words with example sentences before taking a cloze quiz")
+}
+
+// startQuizOfKind ...
+// Starts a quiz of a kind on the learned words that match the word list's filter (when started from a filtered
+// word list) or the configured quiz filter, showing a message if there are no questions to ask.
+func (a *App) startQuizOfKind(kind app.QuizKind, noQuestionsMessage string) {
+	filter := a.configuration.QuizFilter
+	if a.currentScreen == WordListScreen && !a.wordListScreen.GetFilter().IsEmpty() {
+		filter = a.wordListScreen.GetFilter()
+	}
+	if a.quizScreen.Start(kind, filter) == 0 {
+		if !filter.IsEmpty() {
+			noQuestionsMessage += " (quizzing on " + filter.String() + ")"
+		}
+		a.showMessage(noQuestionsMessage, screen.RoleMuted)
+		return
+	}
+	a.currentScreen = QuizScreen
+	a.askQuestion()
+}
+
+// askQuestion ...
+// Records that the current quiz question was asked, and captures typed characters for its answer.
+func (a *App) askQuestion() {
+	a.configuration.RecordQuizzed(a.quizScreen.Current().WordID, a.now())
+	a.writeConfiguration()
+	a.eventListener.CaptureTextInput(a.quizScreen.TypeAnswerCharacter)
+}
+
+// answerQuestion ...
+// Checks the answer to the current quiz question and records it as a graded review of the word.
+func (a *App) answerQuestion() {
+	a.eventListener.ReleaseTextInput()
+	wordID := a.quizScreen.Current().WordID
+	score := 0.0
+	if a.quizScreen.Submit() {
+		score = 1
+	}
+	a.configuration.RecordGrade(wordID, a.now(), score)
+	a.writeConfiguration()
+}
+
+// onMarkLearned ...
+// Called when the word being viewed (or the word of the day) should be marked as learned.
+func (a *App) onMarkLearned() {
+	wordID := a.shownWordID()
+	if wordID == 0 {
+		return
+	}
+	if !a.configuration.MarkWordViewed(wordID, a.now()) {
+		a.showMessage("Word already learned", screen.RoleMuted)
+		return
+	}
+	if a.writeConfiguration() {
+		a.showMessage("Word marked as learned", screen.RoleSuccess)
+	}
+}
+
+// onMarkReviewed ...
+// Called when the word being viewed (or the word of the day) has been reviewed.
+func (a *App) onMarkReviewed() {
+	wordID := a.shownWordID()
+	if wordID == 0 {
+		return
+	}
+	if !a.configuration.RecordReview(wordID, a.now()) {
+		a.showMessage("Learn a word before reviewing it", screen.RoleMuted)
+		return
+	}
+	if a.writeConfiguration() {
+		a.showMessage("Review recorded", screen.RoleSuccess)
+	}
+}
+
+// onResetWord ...
+// Called when the progress of the word being viewed should be reset.
+func (a *App) onResetWord() {
+	if a.currentScreen != WordDetailScreen {
+		return
+	}
+	if !a.configuration.ResetWord(a.wordDetailScreen.GetWordID(), a.now()) {
+		return
+	}
+	if a.writeConfiguration() {
+		a.showMessage("Progress reset", screen.RoleSuccess)
+	}
+}
+
+// onPronounce ...
+// Called when the word being viewed (or the word of the day) should be pronounced in the languages being learned,
+// using the configured pronounce command.
+func (a *App) onPronounce() {
+	word := a.vocabulary.GetWord(a.shownWordID())
+	if word == nil {
+		return
+	}
+	if len(a.configuration.PronounceCommand) == 0 {
+		a.showMessage("Set pronounce-command in the config file to pronounce words", screen.RoleMuted)
+		return
+	}
+
+	var commands [][]string
+	var natives []string
+	for _, translation := range word.StudyTranslations(a.configuration.StudyPair()) {
+		commands = append(commands, app.PronounceCommand(a.configuration.PronounceCommand, translation))
+		natives = append(natives, translation.Native)
+	}
+	if len(commands) == 0 {
+		return
+	}
+	a.showMessage("Pronouncing "+strings.Join(natives, ", "), screen.RoleSuccess)
+
+	// Run the commands in the background so the app stays responsive, telling the user if they fail
+	var err error
+	a.eventListener.RunInBackground(func() {
+		err = a.runCommands(commands)
+	}, func() {
+		if err != nil {
+			log.Print("Unable to run pronounce command. Error: ", err)
+			a.showMessage("Unable to pronounce: "+err.Error(), screen.RoleError)
+		}
+	})
+}
+
+// runCommands ...
+// Runs commands one after another, without the terminal (so they do not disturb the screen). Stops at the first
+// command that cannot be run or fails.
+func runCommands(commands [][]string) error {
+	for _, command := range commands {
+		if err := exec.Command(command[0], command[1:]...).Run(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// shownWordID ...
+// Gets the id of the word shown on the current screen (or zero if the screen does not show a word).
+func (a *App) shownWordID() int {
+	switch a.currentScreen {
+	case WordDetailScreen:
+		return a.wordDetailScreen.GetWordID()
+	case DailyWordScreen:
+		return a.dailyWordScreen.GetWordID()
+	}
+
+	return 0
+}
+
+// writeConfiguration ...
+// Saves configuration, showing a message if it cannot be saved. Returns whether it was saved.
+func (a *App) writeConfiguration() bool {
+	err := a.configuration.WriteConfiguration()
+	if err != nil {
+		log.Print("Unable to save configuration. Error: ", err)
+		a.showMessage("Unable to save configuration", screen.RoleError)
+		return false
+	}
+
+	return true
+}
+
+// showMessage ...
+// Shows a message in the bottom bar, rendering again when it expires so that it disappears.
+func (a *App) showMessage(message string, role screen.Role) {
+	a.bottomBar.ShowMessage(message, role)
+	a.eventListener.Schedule(screens.MessageTimeout, func() {})
+}
+
+// onTick ...
+// Called on each clock tick (the screen is rendered after every tick). Checks for a new day in case the
+// clock jumped (e.g. when the computer wakes from sleep).
+func (a *App) onTick() {
+	a.checkDayRollover()
+}
+
+// scheduleDayRollover ...
+// Schedules a check for a new day when the next study day starts.
+func (a *App) scheduleDayRollover() {
+	now := a.now()
+	delay := a.configuration.NextDayStart(now).Sub(now)
+	if delay < minRolloverDelay {
+		delay = minRolloverDelay
+	}
+	a.eventListener.Schedule(delay, func() {
+		a.checkDayRollover()
+		a.scheduleDayRollover()
+	})
+}
+
+// checkDayRollover ...
+// Moves the daily word screen on to the new day's word if a new study day has started.
+func (a *App) checkDayRollover() {
+	day := a.configuration.StudyDay(a.now())
+	if day.Equal(a.studyDay) {
+		return
+	}
+	a.studyDay = day
+	a.dailyWordScreen.SetDay(day)
+	a.showMessage("A new day has started", screen.RoleSuccess)
+}
+
+// onResize ...
+// Called when the terminal is resized.
+func (a *App) onResize() {
+	io.ClearScreen(0)
+	a.layout()
+}
+
 // onQuit ...
 // Called when the application should quit.
 func (a *App) onQuit() {
@@ -0,0 +1,457 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+func TestOpenWordAndMarkLearned(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	// Open the list and scroll to the second word
+	h.pressKeys("l")
+	h.assertScreenContains("Showing 1 - 3 of 3 total words. Viewed 0.")
+	h.pressKeys("j")
+	h.pressKey(io.KeyArrowDown)
+	h.pressKey(io.KeyArrowUp)
+
+	// Open it
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("au revoir")
+	h.assertScreenContains("used to express good wishes")
+
+	// Mark it learned
+	h.advanceClock(90 * time.Minute)
+	h.pressKeys("m")
+	saved := h.savedConfiguration()
+	if len(saved.ViewedWords) != 1 || saved.ViewedWords[0].ID != 2 {
+		t.Fatalf("Expected word 2 to be saved as viewed, got %+v", saved.ViewedWords)
+	}
+	if saved.ViewedWords[0].MarkedViewedAt != "2018-06-01T10:30:00Z" {
+		t.Errorf("Unexpected viewed time %q", saved.ViewedWords[0].MarkedViewedAt)
+	}
+
+	// Back in the list the word is checked
+	h.pressKey(io.KeyEsc)
+	h.assertScreenContains("Viewed 1.")
+	h.assertScreenContains("✓ [2] goodbye")
+
+	h.pressKeys("q")
+	if h.app.isRunning {
+		t.Error("Expected app to stop after quitting")
+	}
+}
+
+func TestMarkLearnedTwiceKeepsFirstView(t *testing.T) {
+	seeded := configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-30T08:00:00Z"}},
+	}
+	h := newTestHarness(t, 80, 24, seeded)
+
+	h.pressKeys("l")
+	h.pressKey(io.KeyEnter)
+	h.pressKeys("m")
+
+	saved := h.savedConfiguration()
+	if len(saved.ViewedWords) != 1 || saved.ViewedWords[0].MarkedViewedAt != "2018-05-30T08:00:00Z" {
+		t.Errorf("Expected the original view to be kept, got %+v", saved.ViewedWords)
+	}
+}
+
+func TestResizeLaysOutScreens(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	h.pressKeys("l")
+	h.resize(30, 14)
+	h.assertScreenContains("Showing 1 - 1 of 3 total wo║")
+	h.assertScreenContains("[1] hello — bonjour, χα…")
+	h.assertScreenDoesNotContain("goodbye")
+
+	h.resize(100, 30)
+	h.assertScreenContains("Showing 1 - 3 of 3 total words. Viewed 0.")
+}
+
+func TestKeysOnlyApplyToTheirScreen(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	// Enter and mark do nothing on the config screen
+	h.pressKeys("c")
+	h.pressKey(io.KeyEnter)
+	h.pressKeys("m")
+	h.assertScreenContains("Known language")
+	if len(h.savedConfiguration().ViewedWords) != 0 {
+		t.Error("Expected no words to be marked viewed")
+	}
+}
+
+func TestUserThemeIsApplied(t *testing.T) {
+	config := configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		Theme:           "mine",
+		Themes: map[string]configuration.ThemeConfig{
+			"mine": {Base: "light", Colors: map[string]int{"border": 99}},
+		},
+	}
+	h := newTestHarness(t, 80, 24, config)
+
+	if color := h.backend.GetCell(0, 0).FgColor; color != 99 {
+		t.Errorf("Expected border color 99, got %d", color)
+	}
+	if color := h.backend.GetCell(2, 2).FgColor; color != screen.LightTheme.Color(screen.RoleTitle) {
+		t.Errorf("Expected title to use the base theme's title color, got %d", color)
+	}
+}
+
+func TestThemeEditorSavesTheme(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	h.pressKeys("t")
+	h.assertScreenContains("Theme Editor (dark)")
+
+	// Change the title color (from 255) and save as "mine"
+	h.pressKeys("]")
+	h.pressKey(io.KeyArrowUp)
+	h.pressKeys("s")
+	h.assertScreenContains("Save as: custom_")
+	for range "custom" {
+		h.pressKey(io.KeyBackspace2)
+	}
+	h.pressKeys("mine")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("Saved theme mine.")
+
+	config := h.savedConfiguration()
+	if config.Theme != "mine" {
+		t.Errorf("Expected theme mine to be selected, got %q", config.Theme)
+	}
+	if color := config.Themes["mine"].Colors["title"]; color != 254 {
+		t.Errorf("Expected saved title color 254, got %d", color)
+	}
+
+	// The saved theme is applied immediately
+	h.pressKey(io.KeyEsc)
+	if color := h.backend.GetCell(2, 2).FgColor; color != 254 {
+		t.Errorf("Expected title color 254, got %d", color)
+	}
+}
+
+func TestBottomBarShowsStatusAndMessages(t *testing.T) {
+	seeded := configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		StudyLanguage:   "fr",
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	}
+	h := newTestHarness(t, 80, 24, seeded)
+	h.assertScreenContains("Streak: 1 day  Best: 1  Reviews due: 1")
+	h.assertScreenContains("English (US) → French")
+	h.assertScreenContains("q quit")
+
+	h.pressKeys("l")
+	h.assertScreenContains("Enter open")
+	h.pressKeys("j")
+	h.pressKey(io.KeyEnter)
+	h.pressKeys("m")
+	h.assertScreenContains("Word marked as learned")
+	h.assertScreenContains("Streak: 2 days")
+
+	// Messages expire without a key press
+	h.advanceClock(10 * time.Second)
+	h.assertScreenDoesNotContain("Word marked as learned")
+}
+
+func TestDailyWordRollsOverAtDayStart(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us", DayStartHour: 4})
+	h.assertScreenContains("Friday, 1 June 2018")
+	h.assertScreenContains("goodbye")
+
+	// 3:59am still belongs to Friday
+	h.advanceClock(18*time.Hour + 59*time.Minute)
+	h.assertScreenContains("Friday, 1 June 2018")
+
+	// The next word is shown once the new day starts, without a key press
+	h.advanceClock(time.Minute)
+	h.assertScreenContains("Saturday, 2 June 2018")
+	h.assertScreenContains("morning")
+	h.assertScreenContains("A new day has started")
+
+	// Learning it before the next day starts counts towards the new day
+	h.pressKeys("m")
+	saved := h.savedConfiguration()
+	if len(saved.ViewedWords) != 1 || saved.ViewedWords[0].ID != 3 {
+		t.Fatalf("Expected word 3 to be saved as viewed, got %+v", saved.ViewedWords)
+	}
+	h.assertScreenContains("✓ learned")
+	h.assertScreenContains("Streak: 1 day")
+}
+
+func TestReviewsCountTowardsDailyGoal(t *testing.T) {
+	seeded := configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		DailyGoal:       configuration.GoalConfig{NewWords: 1, Reviews: 1},
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	}
+	h := newTestHarness(t, 80, 24, seeded)
+	h.assertScreenContains("Today's goal: 0/1 new words  0/1 reviews")
+	h.assertScreenContains("Goal: 0/2")
+
+	// Today's word is new
+	h.pressKeys("r")
+	h.assertScreenContains("Learn a word before reviewing it")
+	h.pressKeys("m")
+
+	// Review the word learned yesterday
+	h.pressKeys("l")
+	h.pressKey(io.KeyEnter)
+	h.pressKeys("r")
+	h.assertScreenContains("Review recorded")
+	h.assertScreenContains("Goal: 2/2")
+	h.pressKeys("w")
+	h.assertScreenContains("Today's goal: 1/1 new words  1/1 reviews  ✓")
+
+	saved := h.savedConfiguration()
+	if len(saved.Reviews) != 1 || saved.Reviews[0].ID != 1 || saved.ViewedWords[0].ReviewAt != "2018-06-02T00:00:00Z" {
+		t.Errorf("Expected review of word 1 to be saved and its next review scheduled, got %+v %+v", saved.Reviews, saved.ViewedWords)
+	}
+}
+
+func TestStudyEventsAreJournaled(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	h.pressKeys("l")
+	h.pressKey(io.KeyEnter)
+	h.pressKeys("m")
+	h.advanceClock(time.Hour)
+	h.pressKeys("x")
+	h.assertScreenContains("Progress reset")
+
+	saved := h.savedConfiguration()
+	if len(saved.ViewedWords) != 0 {
+		t.Errorf("Expected no learned words after the reset, got %+v", saved.ViewedWords)
+	}
+	var types []configuration.EventType
+	for _, event := range saved.Events() {
+		types = append(types, event.Type)
+	}
+	expected := []configuration.EventType{configuration.EventViewed, configuration.EventLearned, configuration.EventReset}
+	if len(types) != len(expected) || types[0] != expected[0] || types[1] != expected[1] || types[2] != expected[2] {
+		t.Errorf("Expected events %v, got %v", expected, types)
+	}
+}
+
+func TestPronounceWord(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us", StudyLanguages: []string{"fr", "el"}})
+
+	// Without a command, the user is told how to configure one
+	h.pressKeys("l")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("ʒuʁ/")
+	h.pressKeys("a")
+	h.assertScreenContains("Set pronounce-command in the config file")
+	if len(h.commands) != 0 {
+		t.Errorf("Expected no commands to be started, got %v", h.commands)
+	}
+
+	// The command is started for each language being learned
+	h.app.configuration.PronounceCommand = []string{"say", "--lang={language}", "{word}", "{audio}"}
+	h.pressKeys("a")
+	h.assertScreenContains("Pronouncing bonjour, χαίρετε")
+	h.waitForBackgroundTask()
+	h.assertScreenContains("Pronouncing bonjour, χαίρετε")
+	expected := [][]string{
+		{"say", "--lang=fr", "bonjour", filepath.Join("testdata", "audio", "bonjour.ogg")},
+		{"say", "--lang=el", "χαίρετε", ""},
+	}
+	if !reflect.DeepEqual(h.commands, expected) {
+		t.Errorf("Expected commands %q, got %q", expected, h.commands)
+	}
+
+	// The user is told when the command fails
+	h.commandError = errors.New(`exec: "say": executable file not found in $PATH`)
+	h.pressKeys("a")
+	h.waitForBackgroundTask()
+	h.assertScreenContains("Unable to pronounce: exec: \"say\"")
+}
+
+func TestSwitchProfiles(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	})
+
+	// Create a profile, which starts without progress
+	h.pressKeys("u")
+	h.assertScreenContains("Studying as default.")
+	h.pressKeys("nsam")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("Studying as sam")
+	h.assertScreenContains("Streak: 0 days")
+	h.pressKeys("m")
+
+	// Both profiles are on the leaderboard
+	h.pressKeys("p")
+	h.assertScreenContains("Leaderboard")
+	h.assertScreenContains("1. default")
+	h.assertScreenContains("2. sam")
+
+	// Switch back to the default profile
+	h.pressKeys("u")
+	h.pressKeys("k")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("Studying as default")
+	if saved := h.savedConfiguration(); len(saved.ViewedWords) != 1 || saved.ViewedWords[0].ID != 1 {
+		t.Errorf("Expected the default profile's progress to be unchanged, got %+v", saved.ViewedWords)
+	}
+}
+
+func TestQuizRecordsGrades(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		StudyLanguages:  []string{"fr"},
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-30T08:00:00Z"}, {ID: 2, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	})
+
+	h.pressKeys("z")
+	h.assertScreenContains("Question 1 of 2")
+	h.assertScreenContains("Translate into English (US):")
+	h.assertScreenContains("bonjour")
+	h.pressKeys("hello")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("✓ Correct")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("Translate into French:")
+	h.pressKeys("adieu")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("✗ The answer is au revoir")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("Quiz complete: 1/2 correct")
+
+	var types []configuration.EventType
+	for _, event := range h.savedConfiguration().Events() {
+		types = append(types, event.Type)
+	}
+	if len(types) != 6 || types[2] != configuration.EventQuizzed || types[3] != configuration.EventGraded || types[5] != configuration.EventGraded {
+		t.Errorf("Expected each question to be recorded as quizzed then graded, got %v", types)
+	}
+	if reviews := h.savedConfiguration().Reviews; len(reviews) != 2 || !*reviews[0].Correct || *reviews[1].Correct {
+		t.Errorf("Expected a correct then an incorrect review, got %+v", reviews)
+	}
+}
+
+func TestGrammarQuiz(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{DefaultLanguage: "en-us", StudyLanguages: []string{"fr"}})
+
+	h.pressKeys("g")
+	h.assertScreenContains("Learn some words with articles or plurals")
+
+	// The detail screen shows the word's grammar
+	h.pressKeys("l")
+	h.pressKeys("jj")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("le matin (masculine), plural matins")
+	h.pressKeys("m")
+
+	h.pressKeys("g")
+	h.assertScreenContains("Question 1 of 1")
+	h.assertScreenContains("Which article goes with this French word?")
+	h.pressKeys("la")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("✗ The answer is le")
+}
+
+func TestClozeQuiz(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		StudyLanguages:  []string{"fr"},
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-30T08:00:00Z"}, {ID: 2, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	})
+
+	h.pressKeys("b")
+	h.assertScreenContains("Question 1 of 1")
+	h.assertScreenContains("Fill in the blank (French):")
+	h.assertScreenContains("Il est parti sans dire ____.")
+	h.assertScreenContains("He left without saying goodbye.")
+	h.pressKeys("au revoir")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("✓ Correct")
+	if reviews := h.savedConfiguration().Reviews; len(reviews) != 1 || reviews[0].ID != 2 || !*reviews[0].Correct {
+		t.Errorf("Expected a correct review of word 2, got %+v", reviews)
+	}
+}
+
+func TestFilterWordListAndQuiz(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		StudyLanguages:  []string{"fr"},
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-30T08:00:00Z"}, {ID: 3, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	})
+
+	// Filter the list by tag and level
+	h.pressKeys("l")
+	h.pressKeys("/#greetings a3")
+	h.pressKey(io.KeyBackspace)
+	h.pressKeys("1")
+	h.assertScreenContains("Filter: #greetings a1_")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("Filter: A1 #greetings")
+	h.assertScreenContains("Showing 1 - 1 of 1 total words.")
+	h.assertScreenContains("[1] hello — bonjour")
+
+	// A quiz started from the filtered list only asks about the listed words
+	h.pressKeys("z")
+	h.assertScreenContains("Question 1 of 1")
+	h.assertScreenContains("bonjour")
+	h.pressKey(io.KeyEsc)
+
+	// Clearing the filter lists every word again
+	h.pressKeys("l")
+	h.pressKey(io.KeyEsc)
+	h.assertScreenContains("Showing 1 - 3 of 3 total words.")
+
+	// Search text matches translations
+	h.pressKeys("/matin")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("[3] morning — matin")
+	h.pressKey(io.KeyEnter)
+	h.assertScreenContains("A1 · #time · rank 450")
+}
+
+func TestDailyFilter(t *testing.T) {
+	h := newTestHarness(t, 80, 24, configuration.AppConfig{
+		DefaultLanguage:  "en-us",
+		StudyLanguages:   []string{"fr"},
+		DailyFilter:      app.WordFilter{Levels: []string{"A2"}},
+		DailyFilterUntil: "2018-06-01",
+	})
+
+	// Only A2 words are chosen until the filter expires
+	h.assertScreenContains("goodbye")
+	h.advanceClock(24 * time.Hour)
+	h.assertScreenContains("Saturday, 2 June 2018")
+	h.assertScreenContains("morning")
+}
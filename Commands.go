@@ -0,0 +1,336 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/progress"
+	"github.com/stuartthompson/dailyvocab/transliteration"
+)
+
+// RunCommand ...
+// Runs a command given on the command line (instead of the interactive application), writing its output.
+func (a *App) RunCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("no command given")
+	}
+
+	switch args[0] {
+	case "stats":
+		return a.runStatsCommand(args[1:], out)
+	case "sync":
+		return a.runSyncCommand(args[1:], out)
+	case "lint":
+		return a.runLintCommand(args[1:], out)
+	case "romanize":
+		return a.runRomanizeCommand(args[1:], out)
+	case "filter":
+		return a.runFilterCommand(args[1:], out)
+	}
+
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+// runStatsCommand ...
+// Writes statistics about the study history, as text or (with --format json) as JSON.
+func (a *App) runStatsCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(out)
+	format := flags.String("format", "text", "output format (text or json)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	// Read configuration and vocabulary
+	if err := a.configuration.ReadConfiguration(); err != nil {
+		return err
+	}
+	if err := a.vocabulary.LoadFile(a.wordListFileName); err != nil {
+		return err
+	}
+	stats := progress.ComputeStats(a.configuration, a.vocabulary, a.now())
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	accuracy := "-"
+	if stats.QuizAccuracy != nil {
+		accuracy = fmt.Sprintf("%.0f%%", *stats.QuizAccuracy*100)
+	}
+	fmt.Fprintf(out, "Words learned:     %d\n", stats.WordsLearned)
+	fmt.Fprintf(out, "Reviews:           %d\n", stats.Reviews)
+	fmt.Fprintf(out, "Quiz accuracy:     %s\n", accuracy)
+	fmt.Fprintf(out, "Avg. interval:     %.1f days\n", stats.AverageReviewInterval)
+	fmt.Fprintf(out, "Current streak:    %d\n", stats.CurrentStreak)
+	fmt.Fprintf(out, "Longest streak:    %d\n", stats.LongestStreak)
+	fmt.Fprintf(out, "Languages:\n")
+	for _, language := range stats.Languages {
+		fmt.Fprintf(out, "  %-6s %d/%d\n", language.Language, language.Studied, language.Total)
+	}
+	fmt.Fprintf(out, "Learned by month:\n")
+	for _, month := range stats.LearnedByMonth {
+		fmt.Fprintf(out, "  %s %d\n", month.Month, month.Words)
+	}
+
+	return nil
+}
+
+// runLintCommand ...
+// Checks a word list (the application's word list unless a file is given) for problems, writing each one.
+// Fails if any problems are found.
+func (a *App) runLintCommand(args []string, out io.Writer) error {
+	fileName := a.wordListFileName
+	if len(args) > 1 {
+		return errors.New("usage: lint [FILE]")
+	}
+	if len(args) == 1 {
+		fileName = args[0]
+	}
+
+	vocabulary := &app.Vocabulary{}
+	if err := vocabulary.LoadFile(fileName); err != nil {
+		return err
+	}
+	issues := vocabulary.Lint()
+	for _, issue := range issues {
+		fmt.Fprintln(out, issue)
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%d problem(s) found in %s", len(issues), fileName)
+	}
+	fmt.Fprintf(out, "No problems found in %d words.\n", len(vocabulary.Words))
+
+	return nil
+}
+
+// runRomanizeCommand ...
+// Fills in missing anglicized forms in a word list file (the application's word list unless a file is given) by
+// romanizing the native text, writing each one. With --dry-run, the file is left unchanged.
+func (a *App) runRomanizeCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("romanize", flag.ContinueOnError)
+	flags.SetOutput(out)
+	dryRun := flags.Bool("dry-run", false, "report the anglicized forms without writing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	fileName := a.wordListFileName
+	if flags.NArg() > 1 {
+		return errors.New("usage: romanize [--dry-run] [FILE]")
+	}
+	if flags.NArg() == 1 {
+		fileName = flags.Arg(0)
+	}
+
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	content, romanizations, err := transliteration.BackfillFile(content)
+	if err != nil {
+		return fmt.Errorf("%s: %v", fileName, err)
+	}
+	for _, romanization := range romanizations {
+		fmt.Fprintln(out, romanization)
+	}
+	if *dryRun {
+		fmt.Fprintf(out, "Would romanize %d translation(s) in %s\n", len(romanizations), fileName)
+		return nil
+	}
+	if len(romanizations) > 0 {
+		if err := ioutil.WriteFile(fileName, content, 0666); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "Romanized %d translation(s) in %s\n", len(romanizations), fileName)
+
+	return nil
+}
+
+// runFilterCommand ...
+// Sets the filter that words of the day (optionally until a date) or quiz questions are chosen with, e.g.
+// "filter daily --until 2018-06-30 A1 #greetings" (see app.ParseWordFilter). A filter with no criteria selects
+// all words again.
+func (a *App) runFilterCommand(args []string, out io.Writer) error {
+	usage := errors.New("usage: filter daily [--until YYYY-MM-DD] [FILTER...] | filter quiz [FILTER...]")
+	if len(args) == 0 || (args[0] != "daily" && args[0] != "quiz") {
+		return usage
+	}
+	flags := flag.NewFlagSet("filter", flag.ContinueOnError)
+	flags.SetOutput(out)
+	until := flags.String("until", "", "last day (YYYY-MM-DD) on which words of the day are filtered")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *until != "" {
+		if args[0] != "daily" {
+			return usage
+		}
+		if _, err := time.Parse("2006-01-02", *until); err != nil {
+			return fmt.Errorf("invalid date %q", *until)
+		}
+	}
+	filter := app.ParseWordFilter(strings.Join(flags.Args(), " "))
+
+	if err := a.configuration.ReadConfiguration(); err != nil {
+		return err
+	}
+	if args[0] == "daily" {
+		a.configuration.DailyFilter = filter
+		a.configuration.DailyFilterUntil = *until
+	} else {
+		a.configuration.QuizFilter = filter
+	}
+	if err := a.configuration.WriteConfiguration(); err != nil {
+		return err
+	}
+
+	description := "all"
+	if !filter.IsEmpty() {
+		description = filter.String()
+		if *until != "" {
+			description += " until " + *until
+		}
+	}
+	if args[0] == "daily" {
+		fmt.Fprintf(out, "Daily words: %s\n", description)
+	} else {
+		fmt.Fprintf(out, "Quiz words: %s\n", description)
+	}
+
+	return nil
+}
+
+// runSyncCommand ...
+// Merges study progress with a shared directory (e.g. a synced folder or a git working copy), so that progress
+// made on other machines is included, then writes this machine's journal to the directory. Only the selected
+// profile's progress is synced, so several profiles can share a directory. Reports what changed.
+func (a *App) runSyncCommand(args []string, out io.Writer) error {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "dailyvocab"
+	}
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	flags.SetOutput(out)
+	name := flags.String("name", hostname, "name of this machine's journal in the shared directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: sync [--name NAME] DIRECTORY")
+	}
+	dir := flags.Arg(0)
+
+	if err := a.configuration.ReadConfiguration(); err != nil {
+		return err
+	}
+	before := reviewDates(a.configuration)
+
+	// Pull events recorded on other machines
+	profile := a.configuration.ProfileName()
+	shared, err := configuration.ReadSharedHistory(dir, profile)
+	if err != nil {
+		return err
+	}
+	pulled := a.configuration.Merge(shared)
+	if err := a.configuration.WriteConfiguration(); err != nil {
+		return err
+	}
+
+	// Push the events missing from this machine's journal in the shared directory
+	journalFilePath := filepath.Join(dir, configuration.SharedJournalName(*name, profile))
+	pushed, err := configuration.ReadJournal(journalFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	pushed = configuration.MissingEvents(pushed, a.configuration.Events())
+	if err := configuration.AppendJournal(journalFilePath, pushed); err != nil {
+		return err
+	}
+
+	if len(pulled) == 0 && len(pushed) == 0 {
+		fmt.Fprintln(out, "Already up to date.")
+		return nil
+	}
+	fmt.Fprintf(out, "Pulled %d event(s) from %s\n", len(pulled), dir)
+	writeProgressChanges(out, before, reviewDates(a.configuration))
+	fmt.Fprintf(out, "Pushed %d event(s) to %s\n", len(pushed), journalFilePath)
+
+	return nil
+}
+
+// reviewDates ...
+// Gets the next review date of each learned word, by word ID.
+func reviewDates(config *configuration.AppConfig) map[int]string {
+	dates := make(map[int]string)
+	for _, viewed := range config.ViewedWords {
+		date := "-"
+		if reviewAt, err := config.ReviewTime(viewed); err == nil {
+			date = reviewAt.In(config.Location()).Format("2006-01-02")
+		}
+		dates[viewed.ID] = date
+	}
+
+	return dates
+}
+
+// writeProgressChanges ...
+// Writes the words that were learned, reset or rescheduled between two sets of review dates.
+func writeProgressChanges(out io.Writer, before map[int]string, after map[int]string) {
+	var ids []int
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		previous, wasLearned := before[id]
+		next, isLearned := after[id]
+		switch {
+		case !wasLearned:
+			fmt.Fprintf(out, "  word %d: learned (next review %s)\n", id, next)
+		case !isLearned:
+			fmt.Fprintf(out, "  word %d: reset\n", id)
+		case previous != next:
+			fmt.Fprintf(out, "  word %d: next review %s (was %s)\n", id, next, previous)
+		}
+	}
+}
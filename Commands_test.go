@@ -0,0 +1,277 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/progress"
+)
+
+// newCommandApp ...
+// Creates an app for running commands, with seeded configuration and the test word list.
+func newCommandApp(t *testing.T, config configuration.AppConfig) *App {
+	configFilePath := filepath.Join(t.TempDir(), ".dailyvocab")
+	config.TimeZone = "UTC"
+	seedConfiguration(t, configFilePath, config)
+
+	a := NewApp()
+	a.configuration.FilePath = configFilePath
+	a.wordListFileName = filepath.Join("testdata", "wordlist.json")
+	a.now = func() time.Time { return time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC) }
+
+	return a
+}
+
+func TestStatsCommandWritesJSON(t *testing.T) {
+	a := newCommandApp(t, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-31T08:00:00Z"}, {ID: 2, MarkedViewedAt: "2018-06-01T08:00:00Z"}},
+	})
+
+	var out bytes.Buffer
+	if err := a.RunCommand([]string{"stats", "--format", "json"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	var stats progress.Stats
+	if err := json.Unmarshal(out.Bytes(), &stats); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", out.String(), err)
+	}
+	if stats.WordsLearned != 2 || stats.CurrentStreak != 2 || len(stats.StudyDays) != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestStatsCommandWritesText(t *testing.T) {
+	a := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	var out bytes.Buffer
+	if err := a.RunCommand([]string{"stats"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Words learned:     0") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+}
+
+func TestUnknownCommandsFail(t *testing.T) {
+	a := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	for _, args := range [][]string{{"statistics"}, {"stats", "--format", "xml"}} {
+		if err := a.RunCommand(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("Expected %v to fail", args)
+		}
+	}
+}
+
+func TestSyncCommandMergesProgress(t *testing.T) {
+	shared := t.TempDir()
+	laptop := newCommandApp(t, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	})
+	workstation := newCommandApp(t, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		ViewedWords:     []configuration.ViewedWord{{ID: 2, MarkedViewedAt: "2018-05-30T08:00:00Z"}},
+		Reviews:         []configuration.Review{{ID: 2, ReviewedAt: "2018-05-31T08:00:00Z"}},
+	})
+
+	var out bytes.Buffer
+	if err := laptop.RunCommand([]string{"sync", "--name", "laptop", shared}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Pulled 0 event(s)") || !strings.Contains(out.String(), "Pushed 1 event(s)") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+
+	out.Reset()
+	if err := workstation.RunCommand([]string{"sync", "--name", "workstation", shared}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "word 1: learned (next review 2018-06-01)") {
+		t.Errorf("Expected the laptop's progress to be reported, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := laptop.RunCommand([]string{"sync", "--name", "laptop", shared}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "word 2: learned (next review 2018-06-01)") {
+		t.Errorf("Expected the workstation's progress to be reported, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := laptop.RunCommand([]string{"sync", "--name", "laptop", shared}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "Already up to date.\n" {
+		t.Errorf("Expected nothing to sync, got %q", out.String())
+	}
+	laptop.configuration.ReadConfiguration()
+	if len(laptop.configuration.ViewedWords) != 2 || len(laptop.configuration.Reviews) != 1 {
+		t.Errorf("Expected merged progress to be saved, got %+v", laptop.configuration.ViewedWords)
+	}
+}
+
+func TestSyncCommandKeepsProfilesApart(t *testing.T) {
+	shared := t.TempDir()
+	laptop := newCommandApp(t, configuration.AppConfig{
+		DefaultLanguage: "en-us",
+		ViewedWords:     []configuration.ViewedWord{{ID: 1, MarkedViewedAt: "2018-05-31T08:00:00Z"}},
+	})
+	workstation := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+	if err := workstation.configuration.SwitchProfile("alice"); err != nil {
+		t.Fatal(err)
+	}
+	workstation.configuration.MarkWordViewed(2, time.Date(2018, time.May, 30, 8, 0, 0, 0, time.UTC))
+	if err := workstation.configuration.WriteConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	for _, a := range []*App{laptop, workstation, laptop, workstation} {
+		out.Reset()
+		if err := a.RunCommand([]string{"sync", "--name", "host", shared}, &out); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(out.String(), "Pulled") && !strings.Contains(out.String(), "Pulled 0 event(s)") {
+			t.Errorf("Expected nothing to be pulled from the other profile, got %q", out.String())
+		}
+	}
+
+	laptop.configuration.ReadConfiguration()
+	workstation.configuration.ReadConfiguration()
+	if len(laptop.configuration.ViewedWords) != 1 || laptop.configuration.ViewedWords[0].ID != 1 {
+		t.Errorf("Expected only the default profile's word, got %+v", laptop.configuration.ViewedWords)
+	}
+	if len(workstation.configuration.ViewedWords) != 1 || workstation.configuration.ViewedWords[0].ID != 2 {
+		t.Errorf("Expected only alice's word, got %+v", workstation.configuration.ViewedWords)
+	}
+
+	// Each profile's journal is pushed separately, and another machine on the same profile pulls it
+	for _, name := range []string{"host.default.journal", "host.alice.journal"} {
+		if _, err := os.Stat(filepath.Join(shared, name)); err != nil {
+			t.Errorf("Expected journal %s to be pushed: %v", name, err)
+		}
+	}
+	other := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+	if err := other.configuration.SwitchProfile("alice"); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := other.RunCommand([]string{"sync", "--name", "other", shared}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "word 2: learned") || strings.Contains(out.String(), "word 1") {
+		t.Errorf("Expected alice's progress only, got %q", out.String())
+	}
+}
+
+func TestLintCommand(t *testing.T) {
+	a := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	var out bytes.Buffer
+	if err := a.RunCommand([]string{"lint"}, &out); err != nil || out.String() != "No problems found in 3 words.\n" {
+		t.Errorf("Expected the test word list to have no problems, got %q (%v)", out.String(), err)
+	}
+
+	wordListFileName := filepath.Join(t.TempDir(), "wordlist.json")
+	ioutil.WriteFile(wordListFileName, []byte(`[{"id": 1, "translations": [{"languageCode": "tlh", "native": "nuqneH"}]}]`), 0666)
+	out.Reset()
+	err := a.RunCommand([]string{"lint", wordListFileName}, &out)
+	if err == nil || out.String() != "word 1: tlh: unknown language code\n" {
+		t.Errorf("Expected an unknown language to be reported, got %q (%v)", out.String(), err)
+	}
+
+	// Tags are checked against those declared in the header
+	ioutil.WriteFile(wordListFileName, []byte(`{"header": {"tags": ["time"]}, "words": [{"id": 1, "tags": ["tme"], "translations": [{"languageCode": "fr", "native": "matin"}]}]}`), 0666)
+	out.Reset()
+	err = a.RunCommand([]string{"lint", wordListFileName}, &out)
+	if err == nil || out.String() != "word 1: tag \"tme\" is not declared in the header\n" {
+		t.Errorf("Expected an undeclared tag to be reported, got %q (%v)", out.String(), err)
+	}
+}
+
+func TestRomanizeCommand(t *testing.T) {
+	a := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+	wordListFileName := filepath.Join(t.TempDir(), "wordlist.json")
+	content := `[{"id": 1, "translations": [{"languageCode": "en-us", "native": "thank you"}, {"languageCode": "ru", "native": "спасибо"}]}]`
+	ioutil.WriteFile(wordListFileName, []byte(content), 0666)
+
+	var out bytes.Buffer
+	if err := a.RunCommand([]string{"romanize", "--dry-run", wordListFileName}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "word 1: ru спасибо → spasibo\nWould romanize 1 translation(s) in " + wordListFileName + "\n"; out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+	if written, _ := ioutil.ReadFile(wordListFileName); string(written) != content {
+		t.Errorf("Expected a dry run to leave the file unchanged, got %s", written)
+	}
+
+	out.Reset()
+	if err := a.RunCommand([]string{"romanize", wordListFileName}, &out); err != nil {
+		t.Fatal(err)
+	}
+	vocabulary := &app.Vocabulary{}
+	if err := vocabulary.LoadFile(wordListFileName); err != nil {
+		t.Fatal(err)
+	}
+	if anglicized := vocabulary.Words[0].Translations[1].Anglicized; anglicized != "spasibo" {
+		t.Errorf("Expected the anglicized form to be written, got %q", anglicized)
+	}
+}
+
+func TestFilterCommand(t *testing.T) {
+	a := newCommandApp(t, configuration.AppConfig{DefaultLanguage: "en-us"})
+
+	var out bytes.Buffer
+	if err := a.RunCommand([]string{"filter", "daily", "--until", "2018-06-30", "a1", "#greetings"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "Daily words: A1 #greetings until 2018-06-30\n" {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+	out.Reset()
+	if err := a.RunCommand([]string{"filter", "quiz", "top100"}, &out); err != nil || out.String() != "Quiz words: top100\n" {
+		t.Errorf("Unexpected output %q (%v)", out.String(), err)
+	}
+
+	saved := &configuration.AppConfig{FilePath: a.configuration.FilePath}
+	if err := saved.ReadConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+	if saved.DailyFilter.String() != "A1 #greetings" || saved.DailyFilterUntil != "2018-06-30" || saved.QuizFilter.MaxFrequency != 100 {
+		t.Errorf("Expected the filters to be saved, got %+v, %q and %+v", saved.DailyFilter, saved.DailyFilterUntil, saved.QuizFilter)
+	}
+
+	for _, args := range [][]string{{"filter"}, {"filter", "weekly"}, {"filter", "quiz", "--until", "2018-06-30"}, {"filter", "daily", "--until", "June"}} {
+		if err := a.RunCommand(args, &out); err == nil {
+			t.Errorf("Expected %q to fail", args)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+)
+
+// testHarness ...
+// Drives an App through scripted events using an in-memory backend and a fake clock.
+type testHarness struct {
+	t              *testing.T
+	app            *App
+	backend        *io.MemoryBackend
+	now            time.Time // The fake clock's current time
+	configFilePath string
+	commands       [][]string // Commands the app has run
+	commandError   error      // The error running commands fails with (nil to succeed)
+}
+
+// newTestHarness ...
+// Starts an App rendering to an in-memory backend of the specified size.
+// Configuration is written to a temporary directory, seeded with the supplied configuration.
+func newTestHarness(t *testing.T, width int, height int, config configuration.AppConfig) *testHarness {
+	h := &testHarness{
+		t:              t,
+		backend:        io.NewMemoryBackend(width, height),
+		now:            time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC),
+		configFilePath: filepath.Join(t.TempDir(), ".dailyvocab"),
+	}
+	io.SetBackend(h.backend)
+
+	// Seed configuration (counting days in UTC unless a test chooses a time zone)
+	if config.TimeZone == "" {
+		config.TimeZone = "UTC"
+	}
+	seedConfiguration(t, h.configFilePath, config)
+
+	// Start the app
+	h.app = NewApp()
+	h.app.configuration.FilePath = h.configFilePath
+	h.app.wordListFileName = filepath.Join("testdata", "wordlist.json")
+	h.app.now = func() time.Time { return h.now }
+	h.app.getenv = h.getenv
+	h.app.runCommands = func(commands [][]string) error {
+		h.commands = append(h.commands, commands...)
+		return h.commandError
+	}
+	if err := h.app.start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(h.app.eventListener.Stop)
+
+	return h
+}
+
+// seedConfiguration ...
+// Writes a configuration file. Study history in the configuration is written to the file the way old
+// versions stored it, so the app moves it into the journal when it starts.
+func seedConfiguration(t *testing.T, configFilePath string, config configuration.AppConfig) {
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(configFilePath, rawConfig, 0666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// getenv ...
+// Gets environment variables for the app, describing a 256-color terminal.
+func (h *testHarness) getenv(name string) string {
+	if name == "TERM" {
+		return "xterm-256color"
+	}
+
+	return ""
+}
+
+// pressKeys ...
+// Types a sequence of characters, one event per character.
+func (h *testHarness) pressKeys(keys string) {
+	for _, ch := range keys {
+		h.sendEvent(io.Event{Type: io.EventKey, Ch: ch})
+	}
+}
+
+// pressKey ...
+// Presses a special key.
+func (h *testHarness) pressKey(key io.Key) {
+	h.sendEvent(io.Event{Type: io.EventKey, Key: key})
+}
+
+// resize ...
+// Resizes the terminal.
+func (h *testHarness) resize(width int, height int) {
+	h.backend.Resize(width, height)
+	h.sendEvent(io.Event{Type: io.EventResize, Width: width, Height: height})
+}
+
+// advanceClock ...
+// Moves the fake clock forward, running timers that become due (and rendering if any ran).
+func (h *testHarness) advanceClock(d time.Duration) {
+	h.now = h.now.Add(d)
+	if h.app.eventListener.FireDueTimers() > 0 {
+		h.app.Render()
+	}
+}
+
+// waitForBackgroundTask ...
+// Waits for a task the app started in the background to complete, and lets the app render.
+func (h *testHarness) waitForBackgroundTask() {
+	h.app.step()
+}
+
+// sendEvent ...
+// Feeds a single event to the app and lets it render.
+func (h *testHarness) sendEvent(event io.Event) {
+	if !h.app.isRunning {
+		h.t.Fatalf("Event sent after the app quit: %+v", event)
+	}
+	h.backend.QueueEvent(event)
+	h.app.step()
+}
+
+// assertScreenContains ...
+// Fails the test if the rendered screen does not contain the text.
+func (h *testHarness) assertScreenContains(text string) {
+	h.t.Helper()
+	if !strings.Contains(h.backend.String(), text) {
+		h.t.Errorf("Expected screen to contain %q. Screen:\n%s", text, h.backend.String())
+	}
+}
+
+// assertScreenDoesNotContain ...
+// Fails the test if the rendered screen contains the text.
+func (h *testHarness) assertScreenDoesNotContain(text string) {
+	h.t.Helper()
+	if strings.Contains(h.backend.String(), text) {
+		h.t.Errorf("Expected screen not to contain %q. Screen:\n%s", text, h.backend.String())
+	}
+}
+
+// savedConfiguration ...
+// Reads the configuration (and study history replayed from the journal) the app has persisted to disk.
+func (h *testHarness) savedConfiguration() *configuration.AppConfig {
+	h.t.Helper()
+	config := &configuration.AppConfig{FilePath: h.configFilePath}
+	if err := config.ReadConfiguration(); err != nil {
+		h.t.Fatal(err)
+	}
+
+	return config
+}
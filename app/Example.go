@@ -0,0 +1,67 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "strings"
+
+// clozeBlank ...
+// Replaces the word in a sentence for cloze questions.
+const clozeBlank = "____"
+
+// Example ...
+// Represents an example sentence using a word, with the word (in whatever form the sentence uses) marked
+// between square brackets, e.g. "Je dis [bonjour] à tout le monde.".
+type Example struct {
+	Sentence    string `json:"sentence"`
+	Translation string `json:"translation"` // The sentence in the known language (optional)
+}
+
+// Parts ...
+// Splits the sentence into the text before the marked word, the word and the text after it. Returns false if
+// the sentence does not mark a word.
+func (e Example) Parts() (before string, word string, after string, ok bool) {
+	start := strings.Index(e.Sentence, "[")
+	if start < 0 {
+		return e.Sentence, "", "", false
+	}
+	end := strings.Index(e.Sentence[start:], "]")
+	if end <= 1 {
+		return e.Sentence, "", "", false
+	}
+	end += start
+
+	return e.Sentence[:start], e.Sentence[start+1 : end], e.Sentence[end+1:], true
+}
+
+// Text ...
+// Gets the sentence without the marks around the word.
+func (e Example) Text() string {
+	before, word, after, _ := e.Parts()
+	return before + word + after
+}
+
+// Cloze ...
+// Gets the sentence with the marked word blanked out.
+func (e Example) Cloze() string {
+	before, _, after, ok := e.Parts()
+	if !ok {
+		return e.Sentence
+	}
+
+	return before + clozeBlank + after
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "testing"
+
+func TestExampleParts(t *testing.T) {
+	example := Example{Sentence: "Ich wohne in einem [Haus]."}
+	if before, word, after, ok := example.Parts(); !ok || before != "Ich wohne in einem " || word != "Haus" || after != "." {
+		t.Errorf("Unexpected parts %q %q %q (%v)", before, word, after, ok)
+	}
+	if text := example.Text(); text != "Ich wohne in einem Haus." {
+		t.Errorf("Unexpected text %q", text)
+	}
+	if cloze := example.Cloze(); cloze != "Ich wohne in einem ____." {
+		t.Errorf("Unexpected cloze %q", cloze)
+	}
+
+	for _, sentence := range []string{"Ich wohne hier.", "Ein [] Haus.", "Ein [Haus."} {
+		if _, _, _, ok := (Example{Sentence: sentence}).Parts(); ok {
+			t.Errorf("Expected %q not to mark a word", sentence)
+		}
+	}
+}
+
+func TestBuildClozeQuiz(t *testing.T) {
+	words := []*Word{
+		{ID: 1, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "house"}, {LanguageCode: "de", Native: "Haus", Examples: []Example{
+			{Sentence: "Das [Haus] ist alt.", Translation: "The house is old."},
+		}}}},
+		{ID: 2, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "hello"}, {LanguageCode: "de", Native: "hallo", Examples: []Example{{Sentence: "Hallo!"}}}}},
+	}
+
+	questions := BuildClozeQuiz(words, StudyPair{Known: "en-US", Learning: []string{"de"}})
+	if len(questions) != 1 {
+		t.Fatalf("Expected a question for the word with a marked example, got %+v", questions)
+	}
+	question := questions[0]
+	if question.Direction != FillBlank || question.Prompt.Native != "Das ____ ist alt." || question.Hint != "The house is old." || !question.IsCorrect("haus") {
+		t.Errorf("Unexpected question %+v", question)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"sort"
+	"strings"
+)
+
+// Genders ...
+// The grammatical genders a word can have.
+var Genders = []string{"masculine", "feminine", "neuter", "common"}
+
+// IsGender ...
+// Determines whether a string is one of the grammatical genders.
+func IsGender(gender string) bool {
+	for _, g := range Genders {
+		if gender == g {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasGrammar ...
+// Determines whether a word has any grammatical information (gender, article, plural or inflected forms).
+func (w LocalizedWord) HasGrammar() bool {
+	return w.Gender != "" || w.Article != "" || w.Plural != "" || len(w.Forms) > 0
+}
+
+// WithArticle ...
+// Gets a word preceded by its article (joined without a space if the article is elided, as in "l'homme").
+func (w LocalizedWord) WithArticle() string {
+	switch {
+	case w.Article == "":
+		return w.Native
+	case strings.HasSuffix(w.Article, "'") || strings.HasSuffix(w.Article, "’"):
+		return w.Article + w.Native
+	}
+
+	return w.Article + " " + w.Native
+}
+
+// GrammarSummary ...
+// Describes a word's article, gender and plural (e.g. "la maison (feminine), plural maisons").
+func (w LocalizedWord) GrammarSummary() string {
+	summary := w.WithArticle()
+	if w.Gender != "" {
+		summary += " (" + w.Gender + ")"
+	}
+	if w.Plural != "" {
+		summary += ", plural " + w.Plural
+	}
+
+	return summary
+}
+
+// FormNames ...
+// Gets the names of a word's inflected forms, in alphabetical order.
+func (w LocalizedWord) FormNames() []string {
+	names := make([]string, 0, len(w.Forms))
+	for name := range w.Forms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
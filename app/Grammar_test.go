@@ -0,0 +1,59 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "testing"
+
+func TestGrammarSummary(t *testing.T) {
+	for _, test := range []struct {
+		word     LocalizedWord
+		expected string
+	}{
+		{LocalizedWord{Native: "maison", Article: "la", Gender: "feminine", Plural: "maisons"}, "la maison (feminine), plural maisons"},
+		{LocalizedWord{Native: "homme", Article: "l'", Gender: "masculine"}, "l'homme (masculine)"},
+		{LocalizedWord{Native: "Haus", Plural: "Häuser"}, "Haus, plural Häuser"},
+	} {
+		if summary := test.word.GrammarSummary(); summary != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, summary)
+		}
+	}
+}
+
+func TestBuildGrammarQuiz(t *testing.T) {
+	words := []*Word{
+		{ID: 1, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "house"}, {LanguageCode: "de", Native: "Haus", Article: "das", Plural: "Häuser"}}},
+		{ID: 2, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "hello"}, {LanguageCode: "de", Native: "hallo"}}},
+		{ID: 3, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "book"}, {LanguageCode: "de", Native: "Buch", Article: "das", Plural: "Bücher"}}},
+		{ID: 4, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "people"}, {LanguageCode: "de", Native: "Leute", Plural: "Leute"}}},
+	}
+
+	questions := BuildGrammarQuiz(words, StudyPair{Known: "en-US", Learning: []string{"de"}})
+	expected := []struct {
+		id        int
+		direction QuizDirection
+		answer    string
+	}{{1, AskArticle, "das"}, {3, AskPlural, "Bücher"}, {4, AskPlural, "Leute"}}
+	if len(questions) != len(expected) {
+		t.Fatalf("Expected %d questions, got %+v", len(expected), questions)
+	}
+	for i, question := range questions {
+		if question.WordID != expected[i].id || question.Direction != expected[i].direction || question.Answer.Native != expected[i].answer {
+			t.Errorf("Expected question %d to be %+v, got %+v", i+1, expected[i], question)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LanguageTag ...
+// Represents a BCP 47 language tag (e.g. "en-US", "zh-Hant-TW" or "sr-Latn").
+type LanguageTag struct {
+	Language   string   // Primary language subtag, in lower case (e.g. "en")
+	Script     string   // Script subtag, in title case (e.g. "Hant")
+	Region     string   // Region subtag, in upper case (e.g. "US" or "419")
+	Variants   []string // Variant subtags, in lower case (e.g. "1901")
+	Extensions string   // Extension and private use subtags, in lower case (e.g. "u-ca-gregory")
+}
+
+// ParseLanguageTag ...
+// Parses a language tag, normalizing the case of its subtags. Underscores are accepted as separators
+// (e.g. "pt_BR").
+func ParseLanguageTag(code string) (LanguageTag, error) {
+	var tag LanguageTag
+	subtags := strings.FieldsFunc(code, func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 || strings.Count(code, "-")+strings.Count(code, "_") != len(subtags)-1 {
+		return tag, fmt.Errorf("invalid language tag %q", code)
+	}
+	for _, subtag := range subtags {
+		if len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return tag, fmt.Errorf("invalid language tag %q", code)
+		}
+	}
+
+	// Private use tags (e.g. "x-klingon") have no language
+	if strings.EqualFold(subtags[0], "x") {
+		tag.Extensions = strings.ToLower(strings.Join(subtags, "-"))
+		return tag, nil
+	}
+	if len(subtags[0]) < 2 || !isAlphabetic(subtags[0]) {
+		return tag, fmt.Errorf("invalid language %q in tag %q", subtags[0], code)
+	}
+	tag.Language = strings.ToLower(subtags[0])
+
+	for i := 1; i < len(subtags); i++ {
+		subtag := subtags[i]
+		switch {
+		case len(subtag) == 1:
+			// Extensions and private use run to the end of the tag
+			tag.Extensions = strings.ToLower(strings.Join(subtags[i:], "-"))
+			return tag, nil
+		case len(subtag) == 4 && isAlphabetic(subtag) && tag.Script == "" && tag.Region == "" && len(tag.Variants) == 0:
+			tag.Script = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case (len(subtag) == 2 && isAlphabetic(subtag) || len(subtag) == 3 && isNumeric(subtag)) && tag.Region == "" && len(tag.Variants) == 0:
+			tag.Region = strings.ToUpper(subtag)
+		case len(subtag) >= 5 || len(subtag) == 4 && subtag[0] >= '0' && subtag[0] <= '9':
+			tag.Variants = append(tag.Variants, strings.ToLower(subtag))
+		default:
+			return tag, fmt.Errorf("invalid subtag %q in tag %q", subtag, code)
+		}
+	}
+
+	return tag, nil
+}
+
+// String ...
+// Formats the tag (e.g. "zh-Hant-TW").
+func (t LanguageTag) String() string {
+	var subtags []string
+	for _, subtag := range append([]string{t.Language, t.Script, t.Region}, t.Variants...) {
+		if subtag != "" {
+			subtags = append(subtags, subtag)
+		}
+	}
+	if t.Extensions != "" {
+		subtags = append(subtags, t.Extensions)
+	}
+
+	return strings.Join(subtags, "-")
+}
+
+// Parent ...
+// Gets the tag with its most specific subtag removed (e.g. "en" for "en-GB"). Returns false for a
+// tag that is only a language.
+func (t LanguageTag) Parent() (LanguageTag, bool) {
+	parent := t
+	parent.Variants = append([]string(nil), t.Variants...)
+	switch {
+	case parent.Extensions != "":
+		parent.Extensions = ""
+	case len(parent.Variants) > 0:
+		parent.Variants = parent.Variants[:len(parent.Variants)-1]
+	case parent.Region != "":
+		parent.Region = ""
+	case parent.Script != "":
+		parent.Script = ""
+	default:
+		return t, false
+	}
+
+	return parent, parent.Language != "" || parent.Extensions != ""
+}
+
+// NormalizeLanguageCode ...
+// Normalizes the case of a language code (e.g. "en-us" becomes "en-US"). Codes that are not valid tags are
+// only trimmed.
+func NormalizeLanguageCode(code string) string {
+	tag, err := ParseLanguageTag(strings.TrimSpace(code))
+	if err != nil {
+		return strings.TrimSpace(code)
+	}
+
+	return tag.String()
+}
+
+// baseLanguage ...
+// Gets the primary language of a language code (e.g. "pt" for "pt-BR").
+func baseLanguage(code string) string {
+	tag, err := ParseLanguageTag(code)
+	if err != nil {
+		return strings.ToLower(code)
+	}
+
+	return tag.Language
+}
+
+// FallbackChain ...
+// Gets the language codes to try, in order, when looking for a word in a language: the code itself and
+// the languages configured as its fallbacks, then the same for each less specific tag (e.g. "en-GB",
+// then "en"). Fallbacks are keyed by language code.
+func FallbackChain(code string, fallbacks map[string][]string) []string {
+	normalizedFallbacks := make(map[string][]string)
+	for key, codes := range fallbacks {
+		normalizedFallbacks[NormalizeLanguageCode(key)] = codes
+	}
+
+	var chain []string
+	seen := make(map[string]bool)
+	add := func(code string) {
+		if code != "" && !seen[code] {
+			seen[code] = true
+			chain = append(chain, code)
+		}
+	}
+	tag, err := ParseLanguageTag(code)
+	if err != nil {
+		add(strings.TrimSpace(code))
+		return chain
+	}
+	for {
+		add(tag.String())
+		for _, fallback := range normalizedFallbacks[tag.String()] {
+			add(NormalizeLanguageCode(fallback))
+		}
+		parent, ok := tag.Parent()
+		if !ok {
+			break
+		}
+		tag = parent
+	}
+
+	return chain
+}
+
+// Lookup ...
+// Finds a word's best translation into a language, trying each language in its fallback chain and then
+// any translation into the same primary language (so "en-GB" can fall back to "en-US"). Returns the
+// translation and the tag that matched (or nil and an empty string if there is none).
+func (w *Word) Lookup(code string, fallbacks map[string][]string) (*LocalizedWord, string) {
+	for _, candidate := range FallbackChain(code, fallbacks) {
+		if translation := w.Translation(candidate); translation != nil {
+			return translation, candidate
+		}
+	}
+	base := baseLanguage(code)
+	for i := 0; i < len(w.Translations); i++ {
+		if baseLanguage(w.Translations[i].LanguageCode) == base {
+			return &w.Translations[i], NormalizeLanguageCode(w.Translations[i].LanguageCode)
+		}
+	}
+
+	return nil, ""
+}
+
+// isAlphabetic ...
+// Determines whether a string only contains ASCII letters.
+func isAlphabetic(s string) bool {
+	for _, ch := range s {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isNumeric ...
+// Determines whether a string only contains ASCII digits.
+func isNumeric(s string) bool {
+	for _, ch := range s {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAlphanumeric ...
+// Determines whether a string only contains ASCII letters and digits.
+func isAlphanumeric(s string) bool {
+	for _, ch := range s {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLanguageTag(t *testing.T) {
+	for code, expected := range map[string]string{
+		"en-us":        "en-US",
+		"FR":           "fr",
+		"pt_br":        "pt-BR",
+		"zh-hant-tw":   "zh-Hant-TW",
+		"es-419":       "es-419",
+		"de-CH-1901":   "de-CH-1901",
+		"en-US-x-Test": "en-US-x-test",
+		"x-klingon":    "x-klingon",
+	} {
+		tag, err := ParseLanguageTag(code)
+		if err != nil || tag.String() != expected {
+			t.Errorf("Expected %q to parse as %q, got %q (%v)", code, expected, tag.String(), err)
+		}
+	}
+	for _, code := range []string{"", "e", "en--us", "en-", "english-us-too-long", "en-u$", "12"} {
+		if _, err := ParseLanguageTag(code); err == nil {
+			t.Errorf("Expected %q to be rejected", code)
+		}
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	fallbacks := map[string][]string{"pt-br": {"pt-PT"}, "en": {"en-us"}}
+	for code, expected := range map[string]string{
+		"en-GB":      "en-GB en en-US",
+		"pt-BR":      "pt-BR pt-PT pt",
+		"zh-Hant-TW": "zh-Hant-TW zh-Hant zh",
+		"not a tag":  "not a tag",
+	} {
+		if chain := strings.Join(FallbackChain(code, fallbacks), " "); chain != expected {
+			t.Errorf("Expected the fallback chain for %s to be %q, got %q", code, expected, chain)
+		}
+	}
+}
+
+func TestLookupReportsMatchedTag(t *testing.T) {
+	word := &Word{Translations: []LocalizedWord{
+		{LanguageCode: "en-us", Native: "color"},
+		{LanguageCode: "pt-BR", Native: "cor"},
+		{LanguageCode: "fr", Native: "couleur"},
+	}}
+	for code, expected := range map[string]string{"en-GB": "en-US color", "pt": "pt-BR cor", "fr-CA": "fr couleur", "EN-US": "en-US color", "de": " "} {
+		translation, matched := word.Lookup(code, nil)
+		native := ""
+		if translation != nil {
+			native = translation.Native
+		}
+		if matched+" "+native != expected {
+			t.Errorf("Expected %s to match %q, got %q", code, expected, matched+" "+native)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "unicode"
+
+// TextDirection ...
+// Typedef for the directions text is written in.
+type TextDirection int
+
+// Defines text directions.
+const (
+	LeftToRight TextDirection = iota
+	RightToLeft
+)
+
+// Language ...
+// Describes a language in the registry.
+type Language struct {
+	Tag         string        // Language tag (e.g. "pt-BR")
+	EnglishName string        // Name in English (e.g. "Portuguese (Brazil)")
+	NativeName  string        // Name in the language itself (e.g. "português (Brasil)")
+	Script      string        // ISO 15924 code of the script the language is written in (e.g. "Latn")
+	Direction   TextDirection // Direction the language is written in
+	Anglicized  bool          // Whether translations are expected to include an anglicized (romanized) form
+}
+
+// languages ...
+// The language registry.
+var languages = []Language{
+	{Tag: "ar", EnglishName: "Arabic", NativeName: "العربية", Script: "Arab", Direction: RightToLeft, Anglicized: true},
+	{Tag: "de", EnglishName: "German", NativeName: "Deutsch", Script: "Latn"},
+	{Tag: "el", EnglishName: "Greek", NativeName: "Ελληνικά", Script: "Grek", Anglicized: true},
+	{Tag: "en", EnglishName: "English", NativeName: "English", Script: "Latn"},
+	{Tag: "en-GB", EnglishName: "English (UK)", NativeName: "English (UK)", Script: "Latn"},
+	{Tag: "en-US", EnglishName: "English (US)", NativeName: "English (US)", Script: "Latn"},
+	{Tag: "es", EnglishName: "Spanish", NativeName: "español", Script: "Latn"},
+	{Tag: "fa", EnglishName: "Persian", NativeName: "فارسی", Script: "Arab", Direction: RightToLeft, Anglicized: true},
+	{Tag: "fr", EnglishName: "French", NativeName: "français", Script: "Latn"},
+	{Tag: "he", EnglishName: "Hebrew", NativeName: "עברית", Script: "Hebr", Direction: RightToLeft, Anglicized: true},
+	{Tag: "hi", EnglishName: "Hindi", NativeName: "हिन्दी", Script: "Deva", Anglicized: true},
+	{Tag: "it", EnglishName: "Italian", NativeName: "italiano", Script: "Latn"},
+	{Tag: "ja", EnglishName: "Japanese", NativeName: "日本語", Script: "Jpan", Anglicized: true},
+	{Tag: "ko", EnglishName: "Korean", NativeName: "한국어", Script: "Kore", Anglicized: true},
+	{Tag: "nl", EnglishName: "Dutch", NativeName: "Nederlands", Script: "Latn"},
+	{Tag: "pl", EnglishName: "Polish", NativeName: "polski", Script: "Latn"},
+	{Tag: "pt", EnglishName: "Portuguese", NativeName: "português", Script: "Latn"},
+	{Tag: "pt-BR", EnglishName: "Portuguese (Brazil)", NativeName: "português (Brasil)", Script: "Latn"},
+	{Tag: "pt-PT", EnglishName: "Portuguese (Portugal)", NativeName: "português (Portugal)", Script: "Latn"},
+	{Tag: "ru", EnglishName: "Russian", NativeName: "русский", Script: "Cyrl", Anglicized: true},
+	{Tag: "sv", EnglishName: "Swedish", NativeName: "svenska", Script: "Latn"},
+	{Tag: "tr", EnglishName: "Turkish", NativeName: "Türkçe", Script: "Latn"},
+	{Tag: "uk", EnglishName: "Ukrainian", NativeName: "українська", Script: "Cyrl", Anglicized: true},
+	{Tag: "zh", EnglishName: "Chinese", NativeName: "中文", Script: "Hani", Anglicized: true},
+	{Tag: "zh-Hans", EnglishName: "Chinese (Simplified)", NativeName: "简体中文", Script: "Hans", Anglicized: true},
+	{Tag: "zh-Hant", EnglishName: "Chinese (Traditional)", NativeName: "繁體中文", Script: "Hant", Anglicized: true},
+}
+
+// scriptRanges ...
+// The Unicode ranges of the letters used by each script.
+var scriptRanges = map[string][]*unicode.RangeTable{
+	"Arab": {unicode.Arabic},
+	"Cyrl": {unicode.Cyrillic},
+	"Deva": {unicode.Devanagari},
+	"Grek": {unicode.Greek},
+	"Hani": {unicode.Han},
+	"Hans": {unicode.Han},
+	"Hant": {unicode.Han},
+	"Hebr": {unicode.Hebrew},
+	"Jpan": {unicode.Hiragana, unicode.Katakana, unicode.Han},
+	"Kore": {unicode.Hangul, unicode.Han},
+	"Latn": {unicode.Latin},
+}
+
+// LookupLanguage ...
+// Finds a language in the registry. Tags that are not registered themselves are described using their
+// registered primary language (e.g. "fr-CA" is "French (CA)"). Returns false for unknown languages.
+func LookupLanguage(code string) (Language, bool) {
+	tag, err := ParseLanguageTag(code)
+	if err != nil {
+		return Language{Tag: code}, false
+	}
+	for _, candidate := range FallbackChain(tag.String(), nil) {
+		for _, language := range languages {
+			if language.Tag != candidate {
+				continue
+			}
+			if candidate != tag.String() {
+				suffix := tag.String()[len(candidate)+1:]
+				language.Tag = tag.String()
+				language.EnglishName += " (" + suffix + ")"
+				language.NativeName += " (" + suffix + ")"
+			}
+			if tag.Script != "" {
+				language.Script = tag.Script
+			}
+			return language, true
+		}
+	}
+
+	return Language{Tag: tag.String()}, false
+}
+
+// LanguageName ...
+// Gets the English name of a language (or its code if it is not in the registry).
+func LanguageName(code string) string {
+	language, ok := LookupLanguage(code)
+	if !ok {
+		return language.Tag
+	}
+
+	return language.EnglishName
+}
+
+// InScript ...
+// Determines whether a letter belongs to the language's script. Letters of unknown scripts are accepted.
+func (l Language) InScript(ch rune) bool {
+	ranges, ok := scriptRanges[l.Script]
+	if !ok {
+		return true
+	}
+
+	return unicode.In(ch, ranges...)
+}
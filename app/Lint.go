@@ -0,0 +1,114 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// LintIssue ...
+// Describes a problem found in the word list.
+type LintIssue struct {
+	WordID  int
+	Message string
+}
+
+// String ...
+// Formats the issue (e.g. "word 3: fr: native form is empty").
+func (i LintIssue) String() string {
+	return fmt.Sprintf("word %d: %s", i.WordID, i.Message)
+}
+
+// Lint ...
+// Checks the word list for problems: duplicate ids, unknown levels, undeclared tags, invalid or unknown language
+// codes, repeated languages, missing forms, unknown genders, examples without a marked word and words written in
+// the wrong script.
+func (v *Vocabulary) Lint() []LintIssue {
+	var issues []LintIssue
+	report := func(id int, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{WordID: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	declaredTags := make(map[string]bool)
+	for _, tag := range v.Header.Tags {
+		declaredTags[tag] = true
+	}
+
+	ids := make(map[int]bool)
+	for _, word := range v.Words {
+		if ids[word.ID] {
+			report(word.ID, "duplicate word id")
+		}
+		ids[word.ID] = true
+		if word.Level != "" && !IsCEFRLevel(word.Level) {
+			report(word.ID, "unknown CEFR level %q", word.Level)
+		}
+		if word.Frequency < 0 {
+			report(word.ID, "frequency rank %d is not positive", word.Frequency)
+		}
+		for _, tag := range word.Tags {
+			if len(declaredTags) > 0 && !declaredTags[tag] {
+				report(word.ID, "tag %q is not declared in the header", tag)
+			}
+		}
+
+		languageCodes := make(map[string]bool)
+		for _, translation := range word.Translations {
+			code := translation.LanguageCode
+			if _, err := ParseLanguageTag(code); err != nil {
+				report(word.ID, "invalid language code %q", code)
+				continue
+			}
+			code = NormalizeLanguageCode(code)
+			if languageCodes[code] {
+				report(word.ID, "%s: translated more than once", code)
+			}
+			languageCodes[code] = true
+
+			language, ok := LookupLanguage(code)
+			if !ok {
+				report(word.ID, "%s: unknown language code", code)
+			}
+			if strings.TrimSpace(translation.Native) == "" {
+				report(word.ID, "%s: native form is empty", code)
+				continue
+			}
+			if language.Anglicized && translation.Anglicized == "" {
+				report(word.ID, "%s: anglicized form is missing", code)
+			}
+			if translation.Gender != "" && !IsGender(translation.Gender) {
+				report(word.ID, "%s: unknown gender %q", code, translation.Gender)
+			}
+			for _, example := range translation.Examples {
+				if _, _, _, ok := example.Parts(); !ok {
+					report(word.ID, "%s: example %q does not mark the word", code, example.Sentence)
+				}
+			}
+			for _, ch := range translation.Native {
+				if unicode.IsLetter(ch) && !language.InScript(ch) {
+					report(word.ID, "%s: %q is not written in the %s script", code, translation.Native, language.Script)
+					break
+				}
+			}
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupLanguage(t *testing.T) {
+	for code, expected := range map[string]string{"en-us": "English (US)", "fr-CA": "French (CA)", "zh-Hant": "Chinese (Traditional)", "xx": "xx", "not a tag": "not a tag"} {
+		if name := LanguageName(code); name != expected {
+			t.Errorf("Expected %s to be named %q, got %q", code, expected, name)
+		}
+	}
+	if language, ok := LookupLanguage("he"); !ok || language.Direction != RightToLeft || language.Script != "Hebr" || !language.Anglicized {
+		t.Errorf("Unexpected language %+v", language)
+	}
+	if language, _ := LookupLanguage("ru-Latn"); language.Script != "Latn" {
+		t.Errorf("Expected the tag's script to be used, got %s", language.Script)
+	}
+}
+
+func TestLint(t *testing.T) {
+	vocabulary := &Vocabulary{Words: []Word{
+		{ID: 1, Translations: []LocalizedWord{{LanguageCode: "en-us", Native: "hello"}, {LanguageCode: "el", Native: "χαίρετε", Anglicized: "chaírete"}}},
+		{ID: 2, Translations: []LocalizedWord{{LanguageCode: "en-US", Native: "goodbye"}, {LanguageCode: "EN-us", Native: "bye"}, {LanguageCode: "fr", Native: " "}}},
+		{ID: 2, Translations: []LocalizedWord{{LanguageCode: "xx", Native: "word"}, {LanguageCode: "en_", Native: "word"}}},
+		{ID: 3, Translations: []LocalizedWord{{LanguageCode: "ja", Native: "goodbye-japanese"}, {LanguageCode: "el", Native: "xαίρετε", Anglicized: "chaírete"}}},
+		{ID: 4, Translations: []LocalizedWord{{LanguageCode: "de", Native: "Haus", Gender: "neutral", Examples: []Example{{Sentence: "Ein Haus."}}}}},
+		{ID: 5, Tags: []string{"greetings", "travel"}, Level: "a1", Frequency: -1, Translations: []LocalizedWord{{LanguageCode: "de", Native: "hallo"}}},
+	}, Header: WordListHeader{Tags: []string{"greetings"}}}
+
+	var issues []string
+	for _, issue := range vocabulary.Lint() {
+		issues = append(issues, issue.String())
+	}
+	expected := []string{
+		"word 2: en-US: translated more than once",
+		"word 2: fr: native form is empty",
+		"word 2: duplicate word id",
+		"word 2: xx: unknown language code",
+		`word 2: invalid language code "en_"`,
+		"word 3: ja: anglicized form is missing",
+		`word 3: ja: "goodbye-japanese" is not written in the Jpan script`,
+		`word 3: el: "xαίρετε" is not written in the Grek script`,
+		`word 4: de: unknown gender "neutral"`,
+		`word 4: de: example "Ein Haus." does not mark the word`,
+		`word 5: unknown CEFR level "a1"`,
+		"word 5: frequency rank -1 is not positive",
+		`word 5: tag "travel" is not declared in the header`,
+	}
+	if strings.Join(issues, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected issues:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(issues, "\n"))
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "strings"
+
+// FormatIPA ...
+// Formats a word's IPA pronunciation for display, between slashes (or an empty string if it has none).
+func (w LocalizedWord) FormatIPA() string {
+	if w.IPA == "" {
+		return ""
+	}
+
+	return "/" + w.IPA + "/"
+}
+
+// Pronunciation ...
+// Gets how a word is pronounced, for display: its anglicized form and IPA pronunciation (whichever it has).
+func (w LocalizedWord) Pronunciation() string {
+	var parts []string
+	for _, part := range []string{w.Anglicized, w.FormatIPA()} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+// PronounceCommand ...
+// Builds a command that pronounces a word from a template (the command and its arguments), replacing
+// "{word}", "{language}", "{ipa}" and "{audio}" in each argument with the word's native form, language code,
+// IPA pronunciation and recording. Returns nil if the template is empty.
+func PronounceCommand(template []string, word LocalizedWord) []string {
+	if len(template) == 0 {
+		return nil
+	}
+
+	replacer := strings.NewReplacer("{word}", word.Native, "{language}", word.LanguageCode, "{ipa}", word.IPA, "{audio}", word.Audio)
+	command := make([]string, len(template))
+	for i, arg := range template {
+		command[i] = replacer.Replace(arg)
+	}
+
+	return command
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "strings"
+
+// QuizDirection ...
+// Typedef for the directions a quiz question can ask in.
+type QuizDirection int
+
+// Defines quiz directions.
+const (
+	Recognize  QuizDirection = iota // Shows the word in a learning language; asks for it in the known language
+	Recall                          // Shows the word in the known language; asks for it in a learning language
+	AskArticle                      // Shows the word in a learning language; asks for its article
+	AskPlural                       // Shows the word in a learning language; asks for its plural
+	FillBlank                       // Shows a sentence in a learning language with the word blanked out; asks for it
+)
+
+// QuizKind ...
+// Typedef for the kinds of quiz.
+type QuizKind int
+
+// Defines quiz kinds.
+const (
+	VocabularyQuiz QuizKind = iota // Asks for words in another language
+	GrammarQuiz                    // Asks for the articles and plurals of words
+	ClozeQuiz                      // Asks for words blanked out of example sentences
+)
+
+// QuizQuestion ...
+// Represents a question asking for a word in another language (or for its article or plural, in the same
+// language).
+type QuizQuestion struct {
+	WordID    int
+	Direction QuizDirection
+	Prompt    LocalizedWord // The word shown (or the sentence, with the word blanked out)
+	Answer    LocalizedWord // The word asked for
+	Hint      string        // Text shown under the prompt (e.g. the sentence in the known language)
+}
+
+// BuildQuiz ...
+// Builds a question for each word (in order) that has a translation into the known language and a language
+// being learned. Questions alternate between recognizing and recalling words, and cycle through the
+// learning languages each word is translated into.
+func BuildQuiz(words []*Word, pair StudyPair) []QuizQuestion {
+	var questions []QuizQuestion
+	for _, word := range words {
+		known := word.KnownTranslation(pair)
+		learning := word.StudyTranslations(pair)
+		if known == nil || len(learning) == 0 {
+			continue
+		}
+		n := len(questions)
+		question := QuizQuestion{WordID: word.ID, Direction: QuizDirection(n % 2), Prompt: learning[n%len(learning)], Answer: *known}
+		if question.Direction == Recall {
+			question.Prompt, question.Answer = question.Answer, question.Prompt
+		}
+		questions = append(questions, question)
+	}
+
+	return questions
+}
+
+// BuildGrammarQuiz ...
+// Builds a question for each word (in order) that has an article or a plural in a language being learned.
+// Questions alternate between asking for articles and plurals where a word has both, and cycle through the
+// learning languages each word has them in.
+func BuildGrammarQuiz(words []*Word, pair StudyPair) []QuizQuestion {
+	var questions []QuizQuestion
+	for _, word := range words {
+		var inflected []LocalizedWord
+		for _, translation := range word.StudyTranslations(pair) {
+			if translation.Article != "" || translation.Plural != "" {
+				inflected = append(inflected, translation)
+			}
+		}
+		if len(inflected) == 0 {
+			continue
+		}
+		n := len(questions)
+		prompt := inflected[n%len(inflected)]
+		question := QuizQuestion{WordID: word.ID, Direction: AskArticle, Prompt: prompt, Answer: LocalizedWord{LanguageCode: prompt.LanguageCode, Native: prompt.Article}}
+		if prompt.Article == "" || (prompt.Plural != "" && n%2 == 1) {
+			question.Direction = AskPlural
+			question.Answer.Native = prompt.Plural
+		}
+		questions = append(questions, question)
+	}
+
+	return questions
+}
+
+// BuildClozeQuiz ...
+// Builds a question for each word (in order) that has an example sentence in a language being learned, which
+// blanks the word out of the sentence. Questions cycle through the learning languages and the examples each
+// word has.
+func BuildClozeQuiz(words []*Word, pair StudyPair) []QuizQuestion {
+	var questions []QuizQuestion
+	for _, word := range words {
+		var examples []LocalizedWord
+		for _, translation := range word.StudyTranslations(pair) {
+			if len(markedExamples(translation.Examples)) > 0 {
+				examples = append(examples, translation)
+			}
+		}
+		if len(examples) == 0 {
+			continue
+		}
+		n := len(questions)
+		translation := examples[n%len(examples)]
+		marked := markedExamples(translation.Examples)
+		example := marked[n%len(marked)]
+		_, answer, _, _ := example.Parts()
+		questions = append(questions, QuizQuestion{
+			WordID:    word.ID,
+			Direction: FillBlank,
+			Prompt:    LocalizedWord{LanguageCode: translation.LanguageCode, Native: example.Cloze()},
+			Answer:    LocalizedWord{LanguageCode: translation.LanguageCode, Native: answer},
+			Hint:      example.Translation,
+		})
+	}
+
+	return questions
+}
+
+// markedExamples ...
+// Gets the examples that mark the word they use.
+func markedExamples(examples []Example) []Example {
+	var marked []Example
+	for _, example := range examples {
+		if _, _, _, ok := example.Parts(); ok {
+			marked = append(marked, example)
+		}
+	}
+
+	return marked
+}
+
+// IsCorrect ...
+// Determines whether an answer matches the word asked for, ignoring case and surrounding space. Words in
+// other scripts can also be answered with their anglicized form.
+func (q QuizQuestion) IsCorrect(answer string) bool {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return false
+	}
+
+	return strings.EqualFold(answer, q.Answer.Native) || (q.Answer.Anglicized != "" && strings.EqualFold(answer, q.Answer.Anglicized))
+}
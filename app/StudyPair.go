@@ -0,0 +1,128 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import "strings"
+
+// StudyPair ...
+// Represents the language a learner knows and the languages they are learning.
+type StudyPair struct {
+	Known     string              // Language glosses are shown in
+	Learning  []string            // Languages being learned (empty to learn every language other than the known language)
+	Fallbacks map[string][]string // Languages to try, by language code, when a word is not translated into a language
+}
+
+// IsLearning ...
+// Determines whether a language is being learned. Regional variants count as the same language
+// (e.g. "fr-CA" is being learned when "fr" is).
+func (p StudyPair) IsLearning(languageCode string) bool {
+	base := baseLanguage(languageCode)
+	if len(p.Learning) == 0 {
+		return base != baseLanguage(p.Known)
+	}
+	for _, learning := range p.Learning {
+		if baseLanguage(learning) == base {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsKnown ...
+// Determines whether a language is the known language (or a regional variant of it).
+func (p StudyPair) IsKnown(languageCode string) bool {
+	return baseLanguage(languageCode) == baseLanguage(p.Known)
+}
+
+// String ...
+// Describes the pair using the names of its languages (e.g. "English (US) → French, Japanese").
+func (p StudyPair) String() string {
+	return LanguageName(p.Known) + " → " + p.LearningNames()
+}
+
+// LearningNames ...
+// Lists the names of the languages being learned (e.g. "French, Japanese").
+func (p StudyPair) LearningNames() string {
+	if len(p.Learning) == 0 {
+		return "all languages"
+	}
+	var names []string
+	for _, languageCode := range p.Learning {
+		names = append(names, LanguageName(languageCode))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Translation ...
+// Gets a word's translation into exactly a language, ignoring case (or nil if it has none). Use Lookup to
+// fall back to related languages.
+func (w *Word) Translation(languageCode string) *LocalizedWord {
+	languageCode = NormalizeLanguageCode(languageCode)
+	for i := 0; i < len(w.Translations); i++ {
+		if NormalizeLanguageCode(w.Translations[i].LanguageCode) == languageCode {
+			return &w.Translations[i]
+		}
+	}
+
+	return nil
+}
+
+// KnownTranslation ...
+// Gets a word's best translation into the known language (or nil if it has none).
+func (w *Word) KnownTranslation(pair StudyPair) *LocalizedWord {
+	translation, _ := w.Lookup(pair.Known, pair.Fallbacks)
+	return translation
+}
+
+// StudyTranslations ...
+// Gets a word's best translations into the languages being learned (in the order the languages are listed).
+func (w *Word) StudyTranslations(pair StudyPair) []LocalizedWord {
+	var translations []LocalizedWord
+	if len(pair.Learning) == 0 {
+		for _, translation := range w.Translations {
+			if pair.IsLearning(translation.LanguageCode) {
+				translations = append(translations, translation)
+			}
+		}
+		return translations
+	}
+	found := make(map[*LocalizedWord]bool)
+	for _, languageCode := range pair.Learning {
+		if translation, _ := w.Lookup(languageCode, pair.Fallbacks); translation != nil && !found[translation] {
+			found[translation] = true
+			translations = append(translations, *translation)
+		}
+	}
+
+	return translations
+}
+
+// StudyWords ...
+// Gets the words that can be studied: those translated into at least one language being learned.
+func (v *Vocabulary) StudyWords(pair StudyPair) []*Word {
+	var words []*Word
+	for i := 0; i < len(v.Words); i++ {
+		if len(v.Words[i].StudyTranslations(pair)) > 0 {
+			words = append(words, &v.Words[i])
+		}
+	}
+
+	return words
+}
@@ -18,19 +18,31 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"log"
+	"path/filepath"
+	"time"
 )
 
-const wordListFileName = "wordlist.json"
+// WordListFileName ...
+// The name of the word list file.
+const WordListFileName = "wordlist.json"
 
 // LocalizedWord ...
 // Represents a word in a specific language.
 type LocalizedWord struct {
-	LanguageCode string `json:"languageCode"`
-	Native       string `json:"native"`
-	Anglicized   string `json:"anglicized"`
+	LanguageCode string            `json:"languageCode"`
+	Native       string            `json:"native"`
+	Anglicized   string            `json:"anglicized"`
+	IPA          string            `json:"ipa"`      // Pronunciation in the International Phonetic Alphabet (without enclosing slashes)
+	Audio        string            `json:"audio"`    // Path of a recording of the word (relative to the word list file)
+	Gender       string            `json:"gender"`   // Grammatical gender ("masculine", "feminine", "neuter" or "common")
+	Article      string            `json:"article"`  // Article used with the word (e.g. "la" for "maison")
+	Plural       string            `json:"plural"`   // Plural form
+	Forms        map[string]string `json:"forms"`    // Inflected forms by name (e.g. {"present 1sg": "suis"} or {"genitive": "Hauses"})
+	Examples     []Example         `json:"examples"` // Example sentences using the word
 }
 
 // Word ...
@@ -39,12 +51,29 @@ type Word struct {
 	ID           int             `json:"id"`
 	Translations []LocalizedWord `json:"translations"`
 	Usage        []WordUsage     `json:"usage"`
+	Tags         []string        `json:"tags"`      // Topics the word belongs to (e.g. "greetings" or "travel")
+	Level        string          `json:"level"`     // CEFR level ("A1" to "C2")
+	Frequency    int             `json:"frequency"` // Rank of the word by how often it is used (1 for the most common; 0 if unknown)
+}
+
+// WordListHeader ...
+// Describes a word list.
+type WordListHeader struct {
+	Tags []string `json:"tags"` // Tags that words may have (any tags are allowed if none are declared)
 }
 
 // Vocabulary ...
 // Represents a list of words.
 type Vocabulary struct {
-	Words []Word
+	Header WordListHeader
+	Words  []Word
+}
+
+// wordListFile ...
+// The layout of a word list file with a header (files can also be just an array of words).
+type wordListFile struct {
+	Header WordListHeader `json:"header"`
+	Words  []Word         `json:"words"`
 }
 
 // WordUsage ...
@@ -57,20 +86,44 @@ type WordUsage struct {
 // Load ...
 // Loads the word list.
 func (v *Vocabulary) Load() error {
+	return v.LoadFile(WordListFileName)
+}
+
+// LoadFile ...
+// Loads the word list from a specific file.
+func (v *Vocabulary) LoadFile(fileName string) error {
 	// Read word list
-	rawContent, err := ioutil.ReadFile(wordListFileName)
+	rawContent, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		log.Print(err)
 		return err
 	}
 
-	// Unmarshal configuration
-	err = json.Unmarshal(rawContent, &v.Words)
+	// Unmarshal the words (and the header, if the file has one)
+	if trimmed := bytes.TrimSpace(rawContent); len(trimmed) > 0 && trimmed[0] == '{' {
+		var file wordListFile
+		err = json.Unmarshal(rawContent, &file)
+		v.Header, v.Words = file.Header, file.Words
+	} else {
+		v.Header = WordListHeader{}
+		err = json.Unmarshal(rawContent, &v.Words)
+	}
 	if err != nil {
 		log.Print(err)
 		return err
 	}
 
+	// Normalize language codes (e.g. "en-us" becomes "en-US") and resolve recordings against the word list's directory
+	for i := range v.Words {
+		for j := range v.Words[i].Translations {
+			translation := &v.Words[i].Translations[j]
+			translation.LanguageCode = NormalizeLanguageCode(translation.LanguageCode)
+			if translation.Audio != "" && !filepath.IsAbs(translation.Audio) {
+				translation.Audio = filepath.Join(filepath.Dir(fileName), translation.Audio)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -96,8 +149,21 @@ func (v *Vocabulary) GetWord(id int) *Word {
 	return word
 }
 
+// WordForDay ...
+// Gets the word of the day for a date (or nil if there are no words to study). Each day has a different word,
+// cycling through the words that can be studied and match a filter.
+func (v *Vocabulary) WordForDay(day time.Time, pair StudyPair, filter WordFilter) *Word {
+	words := filter.Filter(v.StudyWords(pair))
+	if len(words) == 0 {
+		return nil
+	}
+	days := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+
+	return words[int(days%int64(len(words)))]
+}
+
 // GetWordInLanguage ...
-// Gets a word in a specific language.
+// Gets a word in a specific language (falling back to related languages, e.g. "en" for "en-GB").
 func (v *Vocabulary) GetWordInLanguage(id int, languageCode string) string {
 	// Get the requested word
 	word := v.GetWord(id)
@@ -106,13 +172,11 @@ func (v *Vocabulary) GetWordInLanguage(id int, languageCode string) string {
 		return ""
 	}
 
-	// Get the requested translation for this word
-	var translated string
-	for i := 0; i < len(word.Translations); i++ {
-		if word.Translations[i].LanguageCode == languageCode {
-			translated = word.Translations[i].Native
-		}
+	// Get the best translation for this word
+	translated, _ := word.Lookup(languageCode, nil)
+	if translated == nil {
+		return ""
 	}
 
-	return translated
+	return translated.Native
 }
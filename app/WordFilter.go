@@ -0,0 +1,155 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CEFRLevels ...
+// The levels of the Common European Framework of Reference for Languages, from beginner to proficient.
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+// IsCEFRLevel ...
+// Determines whether a string is a CEFR level (in capitals).
+func IsCEFRLevel(level string) bool {
+	for _, l := range CEFRLevels {
+		if level == l {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WordFilter ...
+// Selects words by tag, CEFR level, frequency and text. Empty criteria match any word.
+type WordFilter struct {
+	Tags         []string `json:"tags"`          // Words must have one of these tags
+	Levels       []string `json:"levels"`        // Words must be at one of these levels
+	MaxFrequency int      `json:"max-frequency"` // Words must be ranked this common or more (e.g. 500 for the 500 most common words)
+	Search       string   `json:"search"`        // Words must contain this text in a translation (ignoring case)
+}
+
+// ParseWordFilter ...
+// Parses a filter from text: levels (e.g. "A1"), tags after a "#" (e.g. "#greetings") and the most common
+// words after "top" (e.g. "top500"), separated by spaces. Any other words are searched for.
+func ParseWordFilter(text string) WordFilter {
+	var filter WordFilter
+	var search []string
+	for _, field := range strings.Fields(text) {
+		rank, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(field), "top"))
+		switch {
+		case IsCEFRLevel(strings.ToUpper(field)):
+			filter.Levels = append(filter.Levels, strings.ToUpper(field))
+		case strings.HasPrefix(field, "#") && len(field) > 1:
+			filter.Tags = append(filter.Tags, strings.ToLower(field[1:]))
+		case strings.HasPrefix(strings.ToLower(field), "top") && err == nil && rank > 0:
+			filter.MaxFrequency = rank
+		default:
+			search = append(search, field)
+		}
+	}
+	filter.Search = strings.Join(search, " ")
+
+	return filter
+}
+
+// String ...
+// Formats the filter the way it is parsed (e.g. "A1 #greetings top500 bon").
+func (f WordFilter) String() string {
+	var fields []string
+	fields = append(fields, f.Levels...)
+	for _, tag := range f.Tags {
+		fields = append(fields, "#"+tag)
+	}
+	if f.MaxFrequency > 0 {
+		fields = append(fields, "top"+strconv.Itoa(f.MaxFrequency))
+	}
+	if f.Search != "" {
+		fields = append(fields, f.Search)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// IsEmpty ...
+// Determines whether the filter matches every word.
+func (f WordFilter) IsEmpty() bool {
+	return len(f.Tags) == 0 && len(f.Levels) == 0 && f.MaxFrequency == 0 && f.Search == ""
+}
+
+// Matches ...
+// Determines whether a word meets all of the filter's criteria.
+func (f WordFilter) Matches(word *Word) bool {
+	if len(f.Levels) > 0 && !containsFold(f.Levels, word.Level) {
+		return false
+	}
+	if len(f.Tags) > 0 {
+		tagged := false
+		for _, tag := range word.Tags {
+			tagged = tagged || containsFold(f.Tags, tag)
+		}
+		if !tagged {
+			return false
+		}
+	}
+	if f.MaxFrequency > 0 && (word.Frequency == 0 || word.Frequency > f.MaxFrequency) {
+		return false
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		for _, translation := range word.Translations {
+			if strings.Contains(strings.ToLower(translation.Native), search) || strings.Contains(strings.ToLower(translation.Anglicized), search) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// Filter ...
+// Gets the words that match the filter.
+func (f WordFilter) Filter(words []*Word) []*Word {
+	if f.IsEmpty() {
+		return words
+	}
+	var matching []*Word
+	for _, word := range words {
+		if f.Matches(word) {
+			matching = append(matching, word)
+		}
+	}
+
+	return matching
+}
+
+// containsFold ...
+// Determines whether a list contains a string, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
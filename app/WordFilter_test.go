@@ -0,0 +1,80 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWordFilter(t *testing.T) {
+	filter := ParseWordFilter("a1 #Greetings  top500 bon jour B2")
+	expected := WordFilter{Tags: []string{"greetings"}, Levels: []string{"A1", "B2"}, MaxFrequency: 500, Search: "bon jour"}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, filter)
+	}
+	if s := filter.String(); s != "A1 B2 #greetings top500 bon jour" {
+		t.Errorf("Unexpected string %q", s)
+	}
+	if filter := ParseWordFilter(" "); !filter.IsEmpty() {
+		t.Errorf("Expected an empty filter, got %+v", filter)
+	}
+}
+
+func TestWordFilterMatches(t *testing.T) {
+	word := &Word{ID: 1, Tags: []string{"greetings"}, Level: "A1", Frequency: 120, Translations: []LocalizedWord{
+		{LanguageCode: "en-US", Native: "hello"},
+		{LanguageCode: "el", Native: "χαίρετε", Anglicized: "chaírete"},
+	}}
+	for text, matches := range map[string]bool{
+		"":                      true,
+		"A1":                    true,
+		"A2 B1":                 false,
+		"#greetings #time":      true,
+		"#time":                 false,
+		"top120":                true,
+		"top100":                false,
+		"HELL":                  true,
+		"chaír":                 true,
+		"A1 #greetings bonjour": false,
+	} {
+		if ParseWordFilter(text).Matches(word) != matches {
+			t.Errorf("Expected filter %q to match: %v", text, matches)
+		}
+	}
+}
+
+func TestWordForDayWithFilter(t *testing.T) {
+	vocabulary := &Vocabulary{Words: []Word{
+		{ID: 1, Level: "A1", Translations: []LocalizedWord{{LanguageCode: "fr", Native: "bonjour"}}},
+		{ID: 2, Level: "B1", Translations: []LocalizedWord{{LanguageCode: "fr", Native: "néanmoins"}}},
+		{ID: 3, Level: "A1", Translations: []LocalizedWord{{LanguageCode: "fr", Native: "matin"}}},
+	}}
+	pair := StudyPair{Known: "en-US", Learning: []string{"fr"}}
+
+	day := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		if word := vocabulary.WordForDay(day.AddDate(0, 0, i), pair, WordFilter{Levels: []string{"A1"}}); word == nil || word.Level != "A1" {
+			t.Errorf("Expected an A1 word, got %+v", word)
+		}
+	}
+	if word := vocabulary.WordForDay(day, pair, WordFilter{Levels: []string{"C2"}}); word != nil {
+		t.Errorf("Expected no word to match, got %+v", word)
+	}
+}
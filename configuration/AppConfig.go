@@ -15,6 +15,8 @@
 // You should have received a copy of the GNU General Public License
 // along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
 
+// Package configuration reads and writes the user's settings and study journal. It builds on value types from
+// the app package (StudyPair and WordFilter), so the app package must never import configuration.
 package configuration
 
 import (
@@ -24,6 +26,10 @@ import (
 	"os"
 	"os/user"
 	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
 )
 
 // configFileName ...
@@ -33,8 +39,31 @@ const configFileName = ".dailyvocab"
 // AppConfig ...
 // Represents configuration for the application.
 type AppConfig struct {
-	DefaultLanguage string       `json:"default-language"`
-	ViewedWords     []ViewedWord `json:"viewed-words"`
+	DefaultLanguage      string                 `json:"default-language"`         // Language the user knows (glosses are shown in it)
+	StudyLanguages       []string               `json:"study-languages"`          // Languages being learned (empty to study all languages)
+	StudyLanguage        string                 `json:"study-language,omitempty"` // Language being learned (only stored by old versions; moved to StudyLanguages)
+	LanguageFallbacks    map[string][]string    `json:"language-fallbacks"`       // Languages to try, by language code, when a word is not translated into a language (e.g. {"pt-BR": ["pt-PT"]})
+	RomanizationUnder    bool                   `json:"romanization-under"`       // Show the romanization of a translation under it (rather than beside it)
+	PronounceCommand     []string               `json:"pronounce-command"`        // Command (and arguments) that pronounces a word, e.g. ["espeak", "-v", "{language}", "{word}"] (see app.PronounceCommand)
+	DailyFilter          app.WordFilter         `json:"daily-filter"`             // Words to choose the word of the day from (e.g. {"levels": ["A1"], "tags": ["greetings"]})
+	DailyFilterUntil     string                 `json:"daily-filter-until"`       // Last study day (YYYY-MM-DD) the daily filter applies to (empty for no end)
+	QuizFilter           app.WordFilter         `json:"quiz-filter"`              // Words to quiz on
+	ViewedWords          []ViewedWord           `json:"viewed-words,omitempty"`   // Learned words (replayed from the journal; only stored here by old versions)
+	Reviews              []Review               `json:"reviews,omitempty"`        // Reviews of learned words, in the order they happened (replayed from the journal)
+	DailyGoal            GoalConfig             `json:"daily-goal"`               // Words to learn and review each day
+	StreakFreezes        bool                   `json:"streak-freezes"`           // Whether exceeding the daily goal earns freezes that keep a streak over a missed day
+	TerminalBidi         bool                   `json:"terminal-bidi"`            // Terminal reorders right-to-left text itself
+	TerminalShapesArabic bool                   `json:"terminal-shapes-arabic"`   // Terminal joins Arabic letters itself
+	ColorMode            string                 `json:"color-mode"`               // Color mode ("auto", "truecolor", "256", "16", "8" or "monochrome")
+	Theme                string                 `json:"theme"`                    // Name of the theme (built-in or user-defined)
+	Themes               map[string]ThemeConfig `json:"themes"`                   // User-defined themes by name
+	TimeZone             string                 `json:"time-zone"`                // Time zone that days are counted in (e.g. "Europe/Paris"; empty for local time)
+	DayStartHour         int                    `json:"day-start-hour"`           // Hour (0-23) at which a new study day starts
+	FilePath             string                 `json:"-"`                        // Path of the configuration file (defaults to ~/.dailyvocab)
+	Profile              string                 `json:"-"`                        // Name of the profile (empty for the default profile)
+	location             *time.Location         // Loaded time zone
+	events               []Event                // Study events, replayed from the journal
+	pendingEvents        []Event                // Study events not yet appended to the journal
 }
 
 // ViewedWord ...
@@ -42,8 +71,21 @@ type AppConfig struct {
 type ViewedWord struct {
 	ID             int    `json:"id"`
 	MarkedViewedAt string `json:"marked-viewed-at"`
+	ReviewAt       string `json:"review-at,omitempty"` // When the word is next due for review (defaults to the start of the next study day)
+}
+
+// Review ...
+// Represents a record indicating when a viewed word was reviewed.
+type Review struct {
+	ID         int    `json:"id"`
+	ReviewedAt string `json:"reviewed-at"`
+	Correct    *bool  `json:"correct,omitempty"` // Whether the word was recalled correctly (nil for reviews that were not graded)
 }
 
+// maxReviewIntervalDays ...
+// The longest time between reviews of a word, in days.
+const maxReviewIntervalDays = 64
+
 // ReadConfiguration ...
 // Reads the application configuration from disk.
 func (a *AppConfig) ReadConfiguration() error {
@@ -55,6 +97,9 @@ func (a *AppConfig) ReadConfiguration() error {
 
 	// Create config if it does not exist
 	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(configFilePath), 0777); err != nil {
+			return err
+		}
 		a.writeDefaultConfiguration(configFilePath)
 	}
 
@@ -70,13 +115,201 @@ func (a *AppConfig) ReadConfiguration() error {
 	json.Unmarshal(rawConfig, &config)
 
 	a.DefaultLanguage = config.DefaultLanguage
-	a.ViewedWords = config.ViewedWords
+	a.StudyLanguages = config.StudyLanguages
+	a.LanguageFallbacks = config.LanguageFallbacks
+	if len(a.StudyLanguages) == 0 && config.StudyLanguage != "" {
+		a.StudyLanguages = []string{config.StudyLanguage}
+	}
+	a.RomanizationUnder = config.RomanizationUnder
+	a.PronounceCommand = config.PronounceCommand
+	a.DailyFilter = config.DailyFilter
+	a.DailyFilterUntil = config.DailyFilterUntil
+	a.QuizFilter = config.QuizFilter
+	a.DailyGoal = config.DailyGoal
+	a.StreakFreezes = config.StreakFreezes
+	a.TerminalBidi = config.TerminalBidi
+	a.TerminalShapesArabic = config.TerminalShapesArabic
+	a.ColorMode = config.ColorMode
+	a.Theme = config.Theme
+	a.Themes = config.Themes
+	a.TimeZone = config.TimeZone
+	a.DayStartHour = config.DayStartHour
+
+	// Replay the journal, first moving any history stored in the configuration file into it
+	journalFilePath := configFilePath + journalFileSuffix
+	events, err := ReadJournal(journalFilePath)
+	if os.IsNotExist(err) {
+		events = legacyEvents(config.ViewedWords, config.Reviews)
+		if err := AppendJournal(journalFilePath, events); err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			log.Print("Moved study history to the journal")
+		}
+	} else if err != nil {
+		return err
+	}
+	a.replay(events)
+	a.pendingEvents = nil
+
 	return nil
 }
 
+// WriteConfiguration ...
+// Writes the application configuration to disk, appending new study events to the journal.
+// Study history is only stored in the journal.
+func (a *AppConfig) WriteConfiguration() error {
+	configFilePath, err := a.buildConfigFilePath()
+	if err != nil {
+		log.Print("Error building config file path.")
+		return err
+	}
+
+	err = AppendJournal(configFilePath+journalFileSuffix, a.pendingEvents)
+	if err != nil {
+		log.Print("Error appending to journal.")
+		return err
+	}
+	a.pendingEvents = nil
+
+	config := *a
+	config.ViewedWords, config.Reviews = nil, nil
+	configJSON, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		log.Print("Error marshaling json.")
+		return err
+	}
+
+	return ioutil.WriteFile(configFilePath, configJSON, 0666)
+}
+
+// MarkWordViewed ...
+// Records that a word was learned at the specified time (in the configured time zone).
+// Returns false if the word had already been learned.
+func (a *AppConfig) MarkWordViewed(id int, at time.Time) bool {
+	if a.findViewedWord(id) != nil {
+		return false
+	}
+	a.record(EventLearned, id, at, nil)
+
+	return true
+}
+
+// RecordReview ...
+// Records that a learned word was reviewed at the specified time, and schedules its next review. The time
+// between reviews doubles with each review of the word. Returns false if the word has not been learned.
+func (a *AppConfig) RecordReview(id int, at time.Time) bool {
+	if a.findViewedWord(id) == nil {
+		return false
+	}
+	a.record(EventReviewed, id, at, nil)
+
+	return true
+}
+
+// RecordGrade ...
+// Records that a learned word was reviewed and graded with a score from 0 to 1, and schedules its next
+// review. Words scoring below a pass are due again the next day. Returns false if the word has not been learned.
+func (a *AppConfig) RecordGrade(id int, at time.Time, score float64) bool {
+	if a.findViewedWord(id) == nil {
+		return false
+	}
+	a.record(EventGraded, id, at, &score)
+
+	return true
+}
+
+// RecordView ...
+// Records that a word was opened.
+func (a *AppConfig) RecordView(id int, at time.Time) {
+	a.record(EventViewed, id, at, nil)
+}
+
+// RecordQuizzed ...
+// Records that a word was asked in a quiz.
+func (a *AppConfig) RecordQuizzed(id int, at time.Time) {
+	a.record(EventQuizzed, id, at, nil)
+}
+
+// ResetWord ...
+// Records that a word's progress was reset, so that it is no longer learned. Returns false if the word
+// has not been learned.
+func (a *AppConfig) ResetWord(id int, at time.Time) bool {
+	if a.findViewedWord(id) == nil {
+		return false
+	}
+	a.record(EventReset, id, at, nil)
+
+	return true
+}
+
+// StudyPair ...
+// Gets the language the user knows and the languages they are learning.
+func (a *AppConfig) StudyPair() app.StudyPair {
+	pair := app.StudyPair{Known: app.NormalizeLanguageCode(a.DefaultLanguage), Fallbacks: a.LanguageFallbacks}
+	for _, languageCode := range a.StudyLanguages {
+		pair.Learning = append(pair.Learning, app.NormalizeLanguageCode(languageCode))
+	}
+
+	return pair
+}
+
+// DueReviews ...
+// Counts the viewed words that are due for review.
+func (a *AppConfig) DueReviews(now time.Time) int {
+	due := 0
+	for _, viewed := range a.ViewedWords {
+		reviewAt, err := a.ReviewTime(viewed)
+		if err == nil && !reviewAt.After(now) {
+			due++
+		}
+	}
+
+	return due
+}
+
+// ReviewTime ...
+// Gets the time at which a viewed word is due for review.
+func (a *AppConfig) ReviewTime(viewed ViewedWord) (time.Time, error) {
+	if viewed.ReviewAt != "" {
+		return time.Parse(time.RFC3339, viewed.ReviewAt)
+	}
+	viewedAt, err := time.Parse(time.RFC3339, viewed.MarkedViewedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return a.NextDayStart(viewedAt), nil
+}
+
+// SaveTheme ...
+// Stores a user-defined theme under a name (replacing any theme with that name) and selects it.
+func (a *AppConfig) SaveTheme(name string, theme ThemeConfig) {
+	if a.Themes == nil {
+		a.Themes = map[string]ThemeConfig{}
+	}
+	a.Themes[name] = theme
+	a.Theme = name
+}
+
 // buildConfigFilePath ...
-// Builds the file path for the application configuration file.
+// Builds the file path for the configuration file of the selected profile.
 func (a *AppConfig) buildConfigFilePath() (string, error) {
+	configFilePath, err := a.buildBaseConfigFilePath()
+	if err != nil || a.Profile == "" || a.Profile == DefaultProfile {
+		return configFilePath, err
+	}
+
+	return filepath.Join(configFilePath+profilesDirSuffix, a.Profile), nil
+}
+
+// buildBaseConfigFilePath ...
+// Builds the file path for the application configuration file (which holds the default profile).
+func (a *AppConfig) buildBaseConfigFilePath() (string, error) {
+	if a.FilePath != "" {
+		return a.FilePath, nil
+	}
+
 	// Get user's home directory
 	usr, err := user.Current()
 	if err != nil {
@@ -0,0 +1,115 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDueReviews(t *testing.T) {
+	config := AppConfig{TimeZone: "UTC", DayStartHour: 4, ViewedWords: []ViewedWord{
+		{ID: 1, MarkedViewedAt: "2018-06-09T08:00:00Z"},
+		{ID: 2, MarkedViewedAt: "2018-06-10T05:00:00Z"},
+		{ID: 3, MarkedViewedAt: "2018-06-01T08:00:00Z", ReviewAt: "2018-06-20T08:00:00Z"},
+		{ID: 4, MarkedViewedAt: "2018-06-10T08:00:00Z", ReviewAt: "2018-06-10T08:30:00Z"},
+	}}
+
+	if due := config.DueReviews(time.Date(2018, time.June, 10, 9, 0, 0, 0, time.UTC)); due != 2 {
+		t.Errorf("Expected 2 reviews due, got %d", due)
+	}
+}
+
+func TestStudyDay(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("Time zone data is not available")
+	}
+	config := AppConfig{TimeZone: "Europe/Paris", DayStartHour: 4}
+	tests := []struct {
+		at       time.Time
+		expected string
+	}{
+		{time.Date(2018, time.June, 10, 9, 0, 0, 0, paris), "2018-06-10"},
+		{time.Date(2018, time.June, 10, 3, 59, 0, 0, paris), "2018-06-09"},
+		{time.Date(2018, time.June, 10, 4, 0, 0, 0, paris), "2018-06-10"},
+		{time.Date(2018, time.June, 10, 1, 30, 0, 0, time.UTC), "2018-06-09"}, // 03:30 in Paris
+	}
+
+	for _, test := range tests {
+		if day := config.StudyDay(test.at).Format("2006-01-02"); day != test.expected {
+			t.Errorf("Expected %v to be in study day %s, got %s", test.at, test.expected, day)
+		}
+	}
+
+	next := config.NextDayStart(time.Date(2018, time.June, 10, 2, 0, 0, 0, paris))
+	if expected := time.Date(2018, time.June, 10, 4, 0, 0, 0, paris); !next.Equal(expected) {
+		t.Errorf("Expected the next day to start at %v, got %v", expected, next)
+	}
+}
+
+func TestStudyDayAcrossDaylightSavingTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("Time zone data is not available")
+	}
+	config := AppConfig{TimeZone: "Europe/Paris", DayStartHour: 4}
+	tests := []struct {
+		at       time.Time
+		expected string
+		next     time.Time
+	}{
+		// Clocks go forward at 02:00 on 25 March 2018 (the study day is an hour shorter)
+		{time.Date(2018, time.March, 25, 3, 30, 0, 0, paris), "2018-03-24", time.Date(2018, time.March, 25, 4, 0, 0, 0, paris)},
+		{time.Date(2018, time.March, 25, 4, 30, 0, 0, paris), "2018-03-25", time.Date(2018, time.March, 26, 4, 0, 0, 0, paris)},
+		// Clocks go back at 03:00 on 28 October 2018 (the study day is an hour longer)
+		{time.Date(2018, time.October, 28, 3, 30, 0, 0, paris), "2018-10-27", time.Date(2018, time.October, 28, 4, 0, 0, 0, paris)},
+		{time.Date(2018, time.October, 28, 4, 0, 0, 0, paris), "2018-10-28", time.Date(2018, time.October, 29, 4, 0, 0, 0, paris)},
+	}
+
+	for _, test := range tests {
+		if day := config.StudyDay(test.at).Format("2006-01-02"); day != test.expected {
+			t.Errorf("Expected %v to be in study day %s, got %s", test.at, test.expected, day)
+		}
+		next := config.NextDayStart(test.at)
+		if !next.Equal(test.next) || !next.After(test.at) {
+			t.Errorf("Expected the day after %v to start at %v, got %v", test.at, test.next, next)
+		}
+	}
+}
+
+func TestRecordReviewDoublesInterval(t *testing.T) {
+	config := AppConfig{TimeZone: "UTC", DayStartHour: 4, ViewedWords: []ViewedWord{{ID: 1, MarkedViewedAt: "2018-06-01T09:00:00Z"}}}
+
+	if config.RecordReview(2, time.Date(2018, time.June, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Error("Expected reviewing a word that was not viewed to fail")
+	}
+	expected := []string{"2018-06-03T04:00:00Z", "2018-06-04T04:00:00Z", "2018-06-06T04:00:00Z"}
+	at := time.Date(2018, time.June, 2, 9, 0, 0, 0, time.UTC)
+	for i, reviewAt := range expected {
+		if !config.RecordReview(1, at) {
+			t.Fatal("Expected review to be recorded")
+		}
+		if config.ViewedWords[0].ReviewAt != reviewAt {
+			t.Errorf("Review %d: expected next review at %s, got %s", i+1, reviewAt, config.ViewedWords[0].ReviewAt)
+		}
+	}
+	if len(config.Reviews) != 3 {
+		t.Errorf("Expected 3 reviews, got %d", len(config.Reviews))
+	}
+}
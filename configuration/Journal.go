@@ -0,0 +1,245 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+// journalFileSuffix ...
+// Suffix added to the configuration file path to give the path of the journal file.
+const journalFileSuffix = ".journal"
+
+// passingScore ...
+// The lowest grading score (from 0 to 1) that counts as recalling a word correctly.
+const passingScore = 0.5
+
+// EventType ...
+// Typedef for the types of study event.
+type EventType string
+
+// Defines study event types.
+const (
+	EventViewed   = EventType("viewed")   // A word was opened
+	EventQuizzed  = EventType("quizzed")  // A word was asked in a quiz
+	EventGraded   = EventType("graded")   // A word was reviewed and graded with a score
+	EventReviewed = EventType("reviewed") // A word was reviewed (without a grade)
+	EventLearned  = EventType("learned")  // A word was marked learned
+	EventReset    = EventType("reset")    // A word's progress was reset
+)
+
+// Event ...
+// Represents a study event recorded in the journal.
+type Event struct {
+	Type  EventType `json:"type"`
+	At    string    `json:"at"` // When the event happened (RFC 3339)
+	ID    int       `json:"id"` // The word the event is about
+	Score *float64  `json:"score,omitempty"`
+}
+
+// Events ...
+// Gets the study events recorded so far (including events not yet written to the journal), oldest first.
+func (a *AppConfig) Events() []Event {
+	return a.events
+}
+
+// record ...
+// Applies a new study event and queues it to be appended to the journal by WriteConfiguration.
+func (a *AppConfig) record(eventType EventType, id int, at time.Time, score *float64) {
+	event := Event{Type: eventType, At: at.In(a.Location()).Format(time.RFC3339), ID: id, Score: score}
+	a.apply(event)
+	a.pendingEvents = append(a.pendingEvents, event)
+}
+
+// apply ...
+// Updates the learned words and reviews (the state derived from the journal) with an event.
+func (a *AppConfig) apply(event Event) {
+	a.events = append(a.events, event)
+
+	switch event.Type {
+	case EventLearned:
+		if a.findViewedWord(event.ID) == nil {
+			a.ViewedWords = append(a.ViewedWords, ViewedWord{ID: event.ID, MarkedViewedAt: event.At})
+		}
+	case EventReviewed, EventGraded:
+		viewed := a.findViewedWord(event.ID)
+		at, err := time.Parse(time.RFC3339, event.At)
+		if viewed == nil || err != nil {
+			return
+		}
+		review := Review{ID: event.ID, ReviewedAt: event.At}
+		if event.Type == EventGraded && event.Score != nil {
+			correct := event.Recalled()
+			review.Correct = &correct
+		}
+
+		// The time between reviews doubles with each review since the word was last recalled incorrectly
+		intervalDays := 1
+		for _, previous := range a.Reviews {
+			if previous.ID != event.ID {
+				continue
+			}
+			if previous.Correct != nil && !*previous.Correct {
+				intervalDays = 1
+			} else if intervalDays < maxReviewIntervalDays {
+				intervalDays *= 2
+			}
+		}
+		if review.Correct != nil && !*review.Correct {
+			intervalDays = 1
+		}
+		a.Reviews = append(a.Reviews, review)
+		viewed.ReviewAt = a.DayStart(a.StudyDay(at).AddDate(0, 0, intervalDays)).Format(time.RFC3339)
+	case EventReset:
+		var viewedWords []ViewedWord
+		for _, viewed := range a.ViewedWords {
+			if viewed.ID != event.ID {
+				viewedWords = append(viewedWords, viewed)
+			}
+		}
+		var reviews []Review
+		for _, review := range a.Reviews {
+			if review.ID != event.ID {
+				reviews = append(reviews, review)
+			}
+		}
+		a.ViewedWords, a.Reviews = viewedWords, reviews
+	}
+}
+
+// replay ...
+// Rebuilds the learned words and reviews from the journal's events, in the order they happened.
+// Events that happened at the same time keep their order in the journal.
+func (a *AppConfig) replay(events []Event) {
+	sorted := append([]Event(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].time().Before(sorted[j].time())
+	})
+
+	a.ViewedWords, a.Reviews, a.events = nil, nil, nil
+	for _, event := range sorted {
+		a.apply(event)
+	}
+}
+
+// Recalled ...
+// Determines whether a graded event's score counts as recalling the word correctly.
+func (e Event) Recalled() bool {
+	return e.Score != nil && *e.Score >= passingScore
+}
+
+// time ...
+// Gets when an event happened (the zero time if it cannot be read).
+func (e Event) time() time.Time {
+	at, _ := time.Parse(time.RFC3339, e.At)
+	return at
+}
+
+// findViewedWord ...
+// Finds the record of a learned word (or nil if the word has not been learned).
+func (a *AppConfig) findViewedWord(id int) *ViewedWord {
+	for i := 0; i < len(a.ViewedWords); i++ {
+		if a.ViewedWords[i].ID == id {
+			return &a.ViewedWords[i]
+		}
+	}
+
+	return nil
+}
+
+// legacyEvents ...
+// Converts learned words and reviews stored in the configuration file (before the journal existed) to events.
+func legacyEvents(viewedWords []ViewedWord, reviews []Review) []Event {
+	var events []Event
+	for _, viewed := range viewedWords {
+		events = append(events, Event{Type: EventLearned, At: viewed.MarkedViewedAt, ID: viewed.ID})
+	}
+	for _, review := range reviews {
+		event := Event{Type: EventReviewed, At: review.ReviewedAt, ID: review.ID}
+		if review.Correct != nil {
+			score := 0.0
+			if *review.Correct {
+				score = 1
+			}
+			event.Type, event.Score = EventGraded, &score
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// ReadJournal ...
+// Reads the events in a journal file, oldest first. Lines that cannot be read (e.g. a line left partly
+// written by a crash) are skipped.
+func ReadJournal(journalFilePath string) ([]Event, error) {
+	file, err := os.Open(journalFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			log.Print("Skipping unreadable journal entry: ", scanner.Text())
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, scanner.Err()
+}
+
+// AppendJournal ...
+// Appends events to a journal file, creating it if it does not exist.
+func AppendJournal(journalFilePath string, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	file, err := os.OpenFile(journalFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	var lines []byte
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+	if _, err := file.Write(lines); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestConfiguration ...
+// Creates configuration stored in a temporary directory.
+func newTestConfiguration(t *testing.T) *AppConfig {
+	config := &AppConfig{FilePath: filepath.Join(t.TempDir(), ".dailyvocab"), TimeZone: "UTC"}
+	if err := config.WriteConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+
+	return config
+}
+
+// rereadConfiguration ...
+// Reads configuration back from the files written by another instance.
+func rereadConfiguration(t *testing.T, config *AppConfig) *AppConfig {
+	reread := &AppConfig{FilePath: config.FilePath}
+	if err := reread.ReadConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+
+	return reread
+}
+
+func TestJournalIsReplayed(t *testing.T) {
+	config := newTestConfiguration(t)
+	at := time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC)
+	config.RecordView(1, at)
+	config.MarkWordViewed(1, at)
+	config.MarkWordViewed(2, at)
+	config.RecordReview(1, at.Add(24*time.Hour))
+	config.RecordGrade(2, at.Add(24*time.Hour), 0.25)
+	config.ResetWord(1, at.Add(48*time.Hour))
+	if err := config.WriteConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+
+	// History is only stored in the journal, one event per line
+	rawConfig, _ := ioutil.ReadFile(config.FilePath)
+	if strings.Contains(string(rawConfig), "viewed-words") {
+		t.Errorf("Expected study history not to be stored in the configuration file:\n%s", rawConfig)
+	}
+	rawJournal, _ := ioutil.ReadFile(config.FilePath + journalFileSuffix)
+	if lines := strings.Count(string(rawJournal), "\n"); lines != 6 {
+		t.Errorf("Expected 6 journal entries, got %d:\n%s", lines, rawJournal)
+	}
+
+	reread := rereadConfiguration(t, config)
+	if len(reread.ViewedWords) != 1 || reread.ViewedWords[0].ID != 2 {
+		t.Fatalf("Expected only word 2 to be learned after word 1 was reset, got %+v", reread.ViewedWords)
+	}
+	if len(reread.Reviews) != 1 || reread.Reviews[0].Correct == nil || *reread.Reviews[0].Correct {
+		t.Errorf("Expected an incorrect review of word 2, got %+v", reread.Reviews)
+	}
+	if reread.ViewedWords[0].ReviewAt != "2018-06-03T00:00:00Z" {
+		t.Errorf("Expected word 2 to be due the day after failing its review, got %s", reread.ViewedWords[0].ReviewAt)
+	}
+	if len(reread.Events()) != 6 {
+		t.Errorf("Expected 6 events, got %d", len(reread.Events()))
+	}
+}
+
+func TestJournalIsOnlyAppended(t *testing.T) {
+	config := newTestConfiguration(t)
+	at := time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC)
+	config.MarkWordViewed(1, at)
+	config.WriteConfiguration()
+	config.WriteConfiguration()
+
+	reread := rereadConfiguration(t, config)
+	reread.MarkWordViewed(2, at)
+	reread.WriteConfiguration()
+
+	events, err := ReadJournal(config.FilePath + journalFileSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].ID != 1 || events[1].ID != 2 {
+		t.Errorf("Expected one event for each word, got %+v", events)
+	}
+}
+
+func TestHistoryMovesToJournal(t *testing.T) {
+	configFilePath := filepath.Join(t.TempDir(), ".dailyvocab")
+	legacy := `{"default-language": "en-us", "time-zone": "UTC", "viewed-words": [{"id": 3, "marked-viewed-at": "2018-06-01T09:00:00Z"}],
+		"reviews": [{"id": 3, "reviewed-at": "2018-06-02T09:00:00Z", "correct": true}]}`
+	if err := ioutil.WriteFile(configFilePath, []byte(legacy), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &AppConfig{FilePath: configFilePath}
+	if err := config.ReadConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.ViewedWords) != 1 || len(config.Reviews) != 1 || config.Reviews[0].Correct == nil || !*config.Reviews[0].Correct {
+		t.Errorf("Expected history to be kept, got %+v %+v", config.ViewedWords, config.Reviews)
+	}
+	events, err := ReadJournal(configFilePath + journalFileSuffix)
+	if err != nil || len(events) != 2 || events[0].Type != EventLearned || events[1].Type != EventGraded {
+		t.Errorf("Expected history to be moved to the journal, got %+v (%v)", events, err)
+	}
+}
+
+func TestUnreadableJournalEntriesAreSkipped(t *testing.T) {
+	journalFilePath := filepath.Join(t.TempDir(), "journal")
+	content := `{"type": "learned", "at": "2018-06-01T09:00:00Z", "id": 1}` + "\n" + `{"type": "lea`
+	if err := ioutil.WriteFile(journalFilePath, []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := ReadJournal(journalFilePath)
+	if err != nil || len(events) != 1 {
+		t.Errorf("Expected the complete entry to be read, got %+v (%v)", events, err)
+	}
+	if _, err := ReadJournal(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing journal to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile ...
+// The name of the profile stored in the application configuration file.
+const DefaultProfile = "default"
+
+// profilesDirSuffix ...
+// Suffix added to the configuration file path to give the path of the directory holding the other profiles.
+const profilesDirSuffix = ".profiles"
+
+// ProfileName ...
+// Gets the name of the selected profile.
+func (a *AppConfig) ProfileName() string {
+	if a.Profile == "" {
+		return DefaultProfile
+	}
+
+	return a.Profile
+}
+
+// ValidateProfileName ...
+// Checks that a profile name is usable (letters, digits, "-" and "_" only).
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+	for _, ch := range name {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+			return fmt.Errorf("invalid profile name %q (use letters, digits, - and _)", name)
+		}
+	}
+
+	return nil
+}
+
+// Profiles ...
+// Lists the names of the profiles, starting with the default profile.
+func (a *AppConfig) Profiles() ([]string, error) {
+	configFilePath, err := a.buildBaseConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(configFilePath + profilesDirSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		if file.IsDir() || strings.HasSuffix(file.Name(), journalFileSuffix) || ValidateProfileName(file.Name()) != nil {
+			continue
+		}
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// ReadProfiles ...
+// Reads the configuration of every profile. The selected profile is included as it is (with any changes not
+// yet written).
+func (a *AppConfig) ReadProfiles() ([]*AppConfig, error) {
+	names, err := a.Profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []*AppConfig
+	for _, name := range names {
+		if name == a.ProfileName() {
+			profiles = append(profiles, a)
+			continue
+		}
+		profile := &AppConfig{FilePath: a.FilePath, Profile: name}
+		if err := profile.ReadConfiguration(); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// SwitchProfile ...
+// Selects another profile and reads its configuration. A profile that does not exist yet is created, sharing
+// the terminal, color and theme settings of the current profile (but not its languages or progress).
+// Changes to the current profile should be written first.
+func (a *AppConfig) SwitchProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	profile := &AppConfig{FilePath: a.FilePath, Profile: name}
+	configFilePath, err := profile.buildConfigFilePath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(configFilePath), 0777); err != nil {
+			return err
+		}
+		profile.DefaultLanguage = "en-us"
+		profile.TerminalBidi = a.TerminalBidi
+		profile.TerminalShapesArabic = a.TerminalShapesArabic
+		profile.RomanizationUnder = a.RomanizationUnder
+		profile.PronounceCommand = a.PronounceCommand
+		profile.ColorMode = a.ColorMode
+		profile.Theme = a.Theme
+		profile.Themes = a.Themes
+		profile.TimeZone = a.TimeZone
+		profile.DayStartHour = a.DayStartHour
+		if err := profile.WriteConfiguration(); err != nil {
+			return err
+		}
+	}
+
+	a.Profile = name
+	if name == DefaultProfile {
+		a.Profile = ""
+	}
+
+	return a.ReadConfiguration()
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSwitchProfile(t *testing.T) {
+	config := newTestConfiguration(t)
+	config.DefaultLanguage = "fr"
+	config.ColorMode = "16"
+	config.MarkWordViewed(1, time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC))
+	if err := config.WriteConfiguration(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new profile shares display settings but not languages or progress
+	if err := config.SwitchProfile("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if config.ProfileName() != "alice" || config.DefaultLanguage != "en-us" || config.ColorMode != "16" || config.TimeZone != "UTC" {
+		t.Errorf("Unexpected new profile %+v", config)
+	}
+	if len(config.ViewedWords) != 0 || len(config.Events()) != 0 {
+		t.Errorf("Expected a new profile to have no progress, got %+v", config.ViewedWords)
+	}
+	config.MarkWordViewed(2, time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC))
+	config.WriteConfiguration()
+
+	if err := config.SwitchProfile(DefaultProfile); err != nil {
+		t.Fatal(err)
+	}
+	if config.Profile != "" || config.DefaultLanguage != "fr" || len(config.ViewedWords) != 1 || config.ViewedWords[0].ID != 1 {
+		t.Errorf("Expected the default profile to be unchanged, got %+v", config)
+	}
+
+	profiles, err := config.ReadProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 || profiles[0] != config || profiles[1].ProfileName() != "alice" || len(profiles[1].ViewedWords) != 1 {
+		t.Errorf("Expected the default and alice profiles, got %+v", profiles)
+	}
+}
+
+func TestSwitchProfileRejectsInvalidNames(t *testing.T) {
+	config := newTestConfiguration(t)
+	for _, name := range []string{"", "../alice", "bob.journal", "carol smith"} {
+		if err := config.SwitchProfile(name); err == nil {
+			t.Errorf("Expected profile name %q to be rejected", name)
+		}
+	}
+	if _, err := os.Stat(config.FilePath + profilesDirSuffix); !os.IsNotExist(err) {
+		t.Errorf("Expected no profiles to be created, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"log"
+	"time"
+)
+
+// Location ...
+// Gets the time zone that study days are counted in (the local time zone unless one is configured).
+func (a *AppConfig) Location() *time.Location {
+	if a.TimeZone == "" {
+		return time.Local
+	}
+	if a.location != nil && a.location.String() == a.TimeZone {
+		return a.location
+	}
+	location, err := time.LoadLocation(a.TimeZone)
+	if err != nil {
+		log.Print("Unknown time zone: ", a.TimeZone)
+		return time.Local
+	}
+	a.location = location
+
+	return location
+}
+
+// StudyDay ...
+// Gets the study day containing a time, as midnight UTC on its date. Study days start at the day-start hour
+// in the configured time zone, so with a day-start hour of 4, 2am counts as part of the previous day. The start
+// is a wall-clock time, so days that change to or from daylight saving time are shorter or longer.
+func (a *AppConfig) StudyDay(t time.Time) time.Time {
+	local := t.In(a.Location())
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	if t.Before(a.DayStart(day)) {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return day
+}
+
+// NextDayStart ...
+// Gets the time at which the study day after the one containing a time starts.
+func (a *AppConfig) NextDayStart(t time.Time) time.Time {
+	return a.DayStart(a.StudyDay(t).AddDate(0, 0, 1))
+}
+
+// DayStart ...
+// Gets the time at which a study day (as returned by StudyDay) starts.
+func (a *AppConfig) DayStart(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), a.dayStartHour(), 0, 0, 0, a.Location())
+}
+
+// dayStartHour ...
+// Gets the hour at which study days start (limited to 0-23).
+func (a *AppConfig) dayStartHour() int {
+	if a.DayStartHour < 0 || a.DayStartHour > 23 {
+		return 0
+	}
+
+	return a.DayStartHour
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Merge ...
+// Adds study events recorded elsewhere (e.g. on another machine) that are not yet known, returning the events
+// added. The added events are appended to the journal by WriteConfiguration. Because events are replayed in the
+// order they happened, merging is deterministic: views are combined and the latest review of a word wins.
+func (a *AppConfig) Merge(events []Event) []Event {
+	added := MissingEvents(a.events, events)
+	if len(added) == 0 {
+		return nil
+	}
+	a.pendingEvents = append(a.pendingEvents, added...)
+	a.replay(append(append([]Event(nil), a.events...), added...))
+
+	return added
+}
+
+// MissingEvents ...
+// Gets the events that are not in a list of known events (skipping duplicates and events with unreadable times).
+func MissingEvents(known []Event, events []Event) []Event {
+	seen := make(map[string]bool)
+	for _, event := range known {
+		seen[event.key()] = true
+	}
+
+	var missing []Event
+	for _, event := range events {
+		if seen[event.key()] || event.time().IsZero() {
+			continue
+		}
+		seen[event.key()] = true
+		missing = append(missing, event)
+	}
+
+	return missing
+}
+
+// key ...
+// Gets a string identifying an event, used to recognize the same event in different journals.
+func (e Event) key() string {
+	score := "-"
+	if e.Score != nil {
+		score = fmt.Sprint(*e.Score)
+	}
+
+	return fmt.Sprintf("%s|%s|%d|%s", e.Type, e.time().UTC().Format("2006-01-02T15:04:05"), e.ID, score)
+}
+
+// SharedJournalName ...
+// Gets the file name of a machine's journal for a profile in a shared directory (e.g. "laptop.alice.journal").
+// Profile names cannot contain ".", so the profile is whatever follows the last "." before the suffix.
+func SharedJournalName(name string, profile string) string {
+	return name + "." + profile + journalFileSuffix
+}
+
+// sharedJournalProfile ...
+// Gets the profile a journal in a shared directory belongs to. Journals named without a profile were written
+// before profiles were synced, and belong to the default profile.
+func sharedJournalProfile(fileName string) string {
+	name := strings.TrimSuffix(fileName, journalFileSuffix)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+
+	return DefaultProfile
+}
+
+// ReadSharedHistory ...
+// Reads a profile's study events in a shared directory (e.g. a synced folder or a git working copy). Journal
+// files (named *.journal) of the profile are read as they are; other files are read as configuration files
+// written by versions that stored history in the configuration, and belong to the default profile.
+// Subdirectories and files that cannot be read are skipped.
+func ReadSharedHistory(dir string, profile string) ([]Event, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		filePath := filepath.Join(dir, file.Name())
+		if strings.HasSuffix(file.Name(), journalFileSuffix) {
+			if sharedJournalProfile(file.Name()) != profile {
+				continue
+			}
+			journal, err := ReadJournal(filePath)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, journal...)
+			continue
+		}
+		if profile != DefaultProfile {
+			continue
+		}
+
+		rawConfig, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			continue
+		}
+		var config AppConfig
+		if json.Unmarshal(rawConfig, &config) != nil {
+			continue
+		}
+		events = append(events, legacyEvents(config.ViewedWords, config.Reviews)...)
+	}
+
+	return events, nil
+}
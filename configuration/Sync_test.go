@@ -0,0 +1,85 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMergeKeepsLatestReview(t *testing.T) {
+	at := time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC)
+	laptop := newTestConfiguration(t)
+	laptop.MarkWordViewed(1, at)
+	laptop.RecordGrade(1, at.Add(24*time.Hour), 1)
+	workstation := newTestConfiguration(t)
+	workstation.MarkWordViewed(1, at.Add(time.Hour))
+	workstation.MarkWordViewed(2, at.Add(time.Hour))
+	workstation.RecordGrade(1, at.Add(48*time.Hour), 0)
+
+	// Merging in either direction gives the same result
+	laptopEvents := laptop.Events()
+	added := laptop.Merge(workstation.Events())
+	workstation.Merge(laptopEvents)
+	if len(added) != 3 {
+		t.Errorf("Expected 3 events to be added, got %+v", added)
+	}
+	for _, config := range []*AppConfig{laptop, workstation} {
+		if len(config.ViewedWords) != 2 || config.ViewedWords[0].MarkedViewedAt != "2018-06-01T09:00:00Z" {
+			t.Errorf("Expected both words to be learned (word 1 when first learned), got %+v", config.ViewedWords)
+		}
+		if config.ViewedWords[0].ReviewAt != "2018-06-04T00:00:00Z" {
+			t.Errorf("Expected the latest (failed) review to schedule word 1, got %s", config.ViewedWords[0].ReviewAt)
+		}
+	}
+
+	// Merging again adds nothing
+	if added := laptop.Merge(workstation.Events()); len(added) != 0 {
+		t.Errorf("Expected no events to be added, got %+v", added)
+	}
+}
+
+func TestReadSharedHistory(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `{"default-language": "en-us", "viewed-words": [{"id": 3, "marked-viewed-at": "2018-06-01T09:00:00Z"}]}`
+	journal := `{"type": "learned", "at": "2018-06-02T09:00:00Z", "id": 4}` + "\n"
+	aliceJournal := `{"type": "learned", "at": "2018-06-02T09:00:00Z", "id": 5}` + "\n"
+	ioutil.WriteFile(filepath.Join(dir, "old-laptop.dailyvocab"), []byte(legacy), 0666)
+	ioutil.WriteFile(filepath.Join(dir, "workstation.journal"), []byte(journal), 0666)
+	ioutil.WriteFile(filepath.Join(dir, SharedJournalName("my.laptop", "alice")), []byte(aliceJournal), 0666)
+	ioutil.WriteFile(filepath.Join(dir, "README"), []byte("Shared progress"), 0666)
+
+	events, err := ReadSharedHistory(dir, DefaultProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].ID != 3 || events[1].ID != 4 {
+		t.Errorf("Expected the legacy configuration and journal to be read, got %+v", events)
+	}
+
+	// Other profiles only read their own journals
+	events, err = ReadSharedHistory(dir, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != 5 {
+		t.Errorf("Expected only alice's journal to be read, got %+v", events)
+	}
+}
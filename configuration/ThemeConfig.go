@@ -0,0 +1,26 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+// ThemeConfig ...
+// Represents a user-defined theme.
+type ThemeConfig struct {
+	Base    string         `json:"base"`    // Name of the built-in theme to start from
+	Colors  map[string]int `json:"colors"`  // Colors by role (e.g. "border", "title")
+	Accents map[string]int `json:"accents"` // Accent colors by language code
+}
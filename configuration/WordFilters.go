@@ -0,0 +1,38 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+)
+
+// DailyWordFilter ...
+// Gets the filter that words of the day are chosen with on a study day (empty once the daily filter has expired).
+// The day must be a date returned by StudyDay, which is compared with DailyFilterUntil as a date (not a time).
+func (a *AppConfig) DailyWordFilter(day time.Time) app.WordFilter {
+	if a.DailyFilterUntil != "" {
+		until, err := time.Parse("2006-01-02", a.DailyFilterUntil)
+		if err == nil && day.After(until) {
+			return app.WordFilter{}
+		}
+	}
+
+	return a.DailyFilter
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+)
+
+func TestDailyWordFilterUntil(t *testing.T) {
+	tests := []struct {
+		timeZone string
+		at       [5]int // Local year, month, day, hour and minute
+		applies  bool
+	}{
+		{"Pacific/Auckland", [5]int{2018, 6, 1, 5, 0}, true},
+		{"Pacific/Auckland", [5]int{2018, 6, 2, 3, 59}, true}, // Before the day starts, so still 1 June
+		{"Pacific/Auckland", [5]int{2018, 6, 2, 4, 0}, false},
+		{"America/Los_Angeles", [5]int{2018, 6, 1, 23, 0}, true}, // Already 2 June in UTC
+		{"America/Los_Angeles", [5]int{2018, 6, 2, 4, 0}, false},
+	}
+	for _, test := range tests {
+		location, err := time.LoadLocation(test.timeZone)
+		if err != nil {
+			t.Skip("Time zone data is not available")
+		}
+		config := AppConfig{
+			TimeZone:         test.timeZone,
+			DayStartHour:     4,
+			DailyFilter:      app.WordFilter{Levels: []string{"A1"}},
+			DailyFilterUntil: "2018-06-01",
+		}
+		at := time.Date(test.at[0], time.Month(test.at[1]), test.at[2], test.at[3], test.at[4], 0, 0, location)
+		filter := config.DailyWordFilter(config.StudyDay(at))
+		if filter.IsEmpty() == test.applies {
+			t.Errorf("%v: expected the filter to apply: %t, got %+v", at, test.applies, filter)
+		}
+	}
+}
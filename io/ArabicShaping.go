@@ -0,0 +1,185 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "unicode"
+
+// arabicJoining ...
+// Typedef for the way an Arabic letter joins to its neighbours.
+type arabicJoining int
+
+// Defines Arabic joining types.
+const (
+	joinsRight = arabicJoining(iota) // Joins only to the preceding letter (e.g. alef, dal, reh, waw)
+	joinsDual                        // Joins to the letters on both sides
+)
+
+// arabicLetter ...
+// Describes the presentation forms of an Arabic letter.
+// Forms are consecutive in the Arabic Presentation Forms blocks: isolated, final, initial, medial.
+type arabicLetter struct {
+	isolated rune
+	joining  arabicJoining
+}
+
+// Arabic letters that are shaped (including the additional letters used in Persian)
+var arabicLetters = map[rune]arabicLetter{
+	'آ': {'\uFE81', joinsRight}, // Alef with madda above
+	'أ': {'\uFE83', joinsRight}, // Alef with hamza above
+	'ؤ': {'\uFE85', joinsRight}, // Waw with hamza above
+	'إ': {'\uFE87', joinsRight}, // Alef with hamza below
+	'ئ': {'\uFE89', joinsDual},  // Yeh with hamza above
+	'ا': {'\uFE8D', joinsRight}, // Alef
+	'ب': {'\uFE8F', joinsDual},  // Beh
+	'ة': {'\uFE93', joinsRight}, // Teh marbuta
+	'ت': {'\uFE95', joinsDual},  // Teh
+	'ث': {'\uFE99', joinsDual},  // Theh
+	'ج': {'\uFE9D', joinsDual},  // Jeem
+	'ح': {'\uFEA1', joinsDual},  // Hah
+	'خ': {'\uFEA5', joinsDual},  // Khah
+	'د': {'\uFEA9', joinsRight}, // Dal
+	'ذ': {'\uFEAB', joinsRight}, // Thal
+	'ر': {'\uFEAD', joinsRight}, // Reh
+	'ز': {'\uFEAF', joinsRight}, // Zain
+	'س': {'\uFEB1', joinsDual},  // Seen
+	'ش': {'\uFEB5', joinsDual},  // Sheen
+	'ص': {'\uFEB9', joinsDual},  // Sad
+	'ض': {'\uFEBD', joinsDual},  // Dad
+	'ط': {'\uFEC1', joinsDual},  // Tah
+	'ظ': {'\uFEC5', joinsDual},  // Zah
+	'ع': {'\uFEC9', joinsDual},  // Ain
+	'غ': {'\uFECD', joinsDual},  // Ghain
+	'ف': {'\uFED1', joinsDual},  // Feh
+	'ق': {'\uFED5', joinsDual},  // Qaf
+	'ك': {'\uFED9', joinsDual},  // Kaf
+	'ل': {'\uFEDD', joinsDual},  // Lam
+	'م': {'\uFEE1', joinsDual},  // Meem
+	'ن': {'\uFEE5', joinsDual},  // Noon
+	'ه': {'\uFEE9', joinsDual},  // Heh
+	'و': {'\uFEED', joinsRight}, // Waw
+	'ى': {'\uFEEF', joinsRight}, // Alef maksura
+	'ي': {'\uFEF1', joinsDual},  // Yeh
+	'پ': {'\uFB56', joinsDual},  // Peh
+	'چ': {'\uFB7A', joinsDual},  // Tcheh
+	'ژ': {'\uFB8A', joinsRight}, // Jeh
+	'ک': {'\uFB8E', joinsDual},  // Keheh
+	'گ': {'\uFB92', joinsDual},  // Gaf
+	'ی': {'\uFBFC', joinsDual},  // Farsi yeh
+}
+
+// Runes involved in the mandatory lam-alef ligatures
+const (
+	arabicLam     = rune('ل')
+	arabicTatweel = rune('ـ')
+)
+
+// lamAlefLigatures ...
+// The isolated form of the lam-alef ligature for each alef (the final form follows it).
+var lamAlefLigatures = map[rune]rune{
+	'آ': '\uFEF5',
+	'أ': '\uFEF7',
+	'إ': '\uFEF9',
+	'ا': '\uFEFB',
+}
+
+// ShapeArabic ...
+// Replaces Arabic letters with the contextual presentation forms (isolated, initial, medial or final) that
+// connect them to their neighbours. This is needed on terminals that do not shape Arabic script themselves.
+// Text must be in logical order.
+func ShapeArabic(text string) string {
+	runes := []rune(text)
+	shaped := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		letter, ok := arabicLetters[r]
+		if !ok {
+			shaped = append(shaped, r)
+			continue
+		}
+
+		joinsPrevious := joinsToNext(previousLetter(runes, i))
+		next, nextIx := nextLetter(runes, i)
+
+		// Lam followed by alef forms a mandatory ligature
+		if ligature, ok := lamAlefLigatures[next]; ok && r == arabicLam {
+			if joinsPrevious {
+				ligature++
+			}
+			shaped = append(shaped, ligature)
+			// Keep any marks between the lam and the alef, dropping the alef itself
+			shaped = append(shaped, runes[i+1:nextIx]...)
+			i = nextIx
+			continue
+		}
+
+		_, nextIsLetter := arabicLetters[next]
+		joinsNext := letter.joining == joinsDual && (nextIsLetter || next == arabicTatweel)
+		shaped = append(shaped, letter.form(joinsPrevious, joinsNext))
+	}
+
+	return string(shaped)
+}
+
+// form ...
+// Gets the presentation form of a letter given whether it connects to the letters either side of it.
+func (l arabicLetter) form(joinsPrevious bool, joinsNext bool) rune {
+	switch {
+	case joinsPrevious && joinsNext:
+		return l.isolated + 3 // Medial
+	case joinsNext:
+		return l.isolated + 2 // Initial
+	case joinsPrevious:
+		return l.isolated + 1 // Final
+	default:
+		return l.isolated
+	}
+}
+
+// joinsToNext ...
+// Determines whether a rune connects to the letter that follows it.
+func joinsToNext(r rune) bool {
+	if r == arabicTatweel {
+		return true
+	}
+	letter, ok := arabicLetters[r]
+	return ok && letter.joining == joinsDual
+}
+
+// previousLetter ...
+// Gets the closest rune before an index, skipping marks (which do not affect joining).
+func previousLetter(runes []rune, ix int) rune {
+	for i := ix - 1; i >= 0; i-- {
+		if !unicode.Is(unicode.Mn, runes[i]) {
+			return runes[i]
+		}
+	}
+
+	return 0
+}
+
+// nextLetter ...
+// Gets the closest rune after an index (and its index), skipping marks.
+func nextLetter(runes []rune, ix int) (rune, int) {
+	for i := ix + 1; i < len(runes); i++ {
+		if !unicode.Is(unicode.Mn, runes[i]) {
+			return runes[i], i
+		}
+	}
+
+	return 0, len(runes)
+}
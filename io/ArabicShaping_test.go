@@ -0,0 +1,47 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "testing"
+
+func TestShapeArabic(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"isolated", "ب", "\uFE8F"},
+		{"initial and final", "بب", "\uFE91\uFE90"},
+		{"medial", "ببب", "\uFE91\uFE92\uFE90"},
+		{"right-joining letters do not join the next letter", "دب", "\uFEA9\uFE8F"},
+		{"marks do not break joining", "بَب", "\uFE91َ\uFE90"},
+		{"tatweel", "بـ", "\uFE91ـ"},
+		{"Persian letters", "پ", "\uFB56"},
+		{"lam-alef ligature", "لا", "\uFEFB"},
+		{"lam-alef with hamza", "لأ", "\uFEF7"},
+		{"joined lam-alef ligature", "بلا", "\uFE91\uFEFC"},
+		{"letter after lam-alef", "سلام", "\uFEB3\uFEFC\uFEE1"},
+		{"words shape separately", "بب بب", "\uFE91\uFE90 \uFE91\uFE90"},
+		{"other text is unchanged", "abc שלום", "abc שלום"},
+	}
+	for _, test := range tests {
+		if shaped := ShapeArabic(test.text); shaped != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, shaped)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+// Backend ...
+// Represents a surface that the renderer draws cells to (e.g. the terminal).
+type Backend interface {
+	Init() error
+	Close()
+	SetCell(x int, y int, ch rune, fgColor int, bgColor int)
+	Clear(bgColor int)
+	Flush()
+	Size() (width int, height int)
+	PollEvent() Event
+	Interrupt()
+	SetColorMode(mode ColorMode)
+}
+
+// backend ...
+// The backend that all rendering is drawn to.
+var backend Backend = &TermboxBackend{}
+
+// SetBackend ...
+// Sets the backend that all rendering is drawn to.
+func SetBackend(b Backend) {
+	backend = b
+	buffer = &renderBuffer{}
+}
+
+// Init ...
+// Initializes the backend.
+func Init() error {
+	return backend.Init()
+}
+
+// Close ...
+// Closes the backend.
+func Close() {
+	backend.Close()
+}
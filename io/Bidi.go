@@ -0,0 +1,229 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "golang.org/x/text/unicode/bidi"
+
+// Direction ...
+// Typedef for text direction.
+type Direction int
+
+// Defines text directions.
+const (
+	LeftToRight = Direction(iota)
+	RightToLeft
+)
+
+// Bidi options (see ConfigureBidi)
+var (
+	reorderBidiText = true
+	shapeArabicText = true
+)
+
+// mirroredRunes ...
+// Paired punctuation that is mirrored when it appears within right-to-left text.
+var mirroredRunes = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+// ConfigureBidi ...
+// Configures how right-to-left text is prepared before it is sent to the terminal.
+// Terminals that implement the bidi algorithm (or Arabic shaping) themselves should be sent text in logical order.
+func ConfigureBidi(reorder bool, shapeArabic bool) {
+	reorderBidiText = reorder
+	shapeArabicText = shapeArabic
+}
+
+// TextDirection ...
+// Gets the base direction of a string, which is the direction of its first strongly directional character.
+func TextDirection(text string) Direction {
+	for _, r := range text {
+		switch bidiClass(r) {
+		case bidi.L:
+			return LeftToRight
+		case bidi.R, bidi.AL:
+			return RightToLeft
+		}
+	}
+
+	return LeftToRight
+}
+
+// VisualText ...
+// Reorders a single line of text from logical (typed) order into the left-to-right order in which it is displayed.
+// This is a simplified form of the Unicode bidi algorithm without explicit embeddings, which is sufficient for
+// words and short labels that mix right-to-left and left-to-right text.
+func VisualText(text string) string {
+	graphemes := Graphemes(text)
+	levels := resolveLevels(graphemes, TextDirection(text))
+
+	// Reverse runs, from the highest level down to the lowest odd level
+	maxLevel := 0
+	for _, level := range levels {
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+	for level := maxLevel; level > 0; level-- {
+		for start := 0; start < len(graphemes); start++ {
+			if levels[start] < level {
+				continue
+			}
+			end := start
+			for end < len(graphemes) && levels[end] >= level {
+				end++
+			}
+			reverseGraphemes(graphemes[start:end])
+			reverseInts(levels[start:end])
+			start = end
+		}
+	}
+
+	// Build the visual string, mirroring paired punctuation in right-to-left runs
+	visual := []rune{}
+	for i, grapheme := range graphemes {
+		if mirrored, ok := mirroredRunes[grapheme.Runes[0]]; ok && levels[i]%2 == 1 {
+			visual = append(visual, mirrored)
+			visual = append(visual, grapheme.Runes[1:]...)
+			continue
+		}
+		visual = append(visual, grapheme.Runes...)
+	}
+
+	return string(visual)
+}
+
+// prepareText ...
+// Prepares text for the terminal according to the configured bidi options.
+func prepareText(text string) string {
+	if shapeArabicText {
+		text = ShapeArabic(text)
+	}
+	if reorderBidiText {
+		text = VisualText(text)
+	}
+
+	return text
+}
+
+// resolveLevels ...
+// Resolves the embedding level of each grapheme (even levels are left-to-right, odd are right-to-left).
+func resolveLevels(graphemes []Grapheme, base Direction) []int {
+	baseLevel := int(base)
+
+	// Classify each grapheme as strong left, strong right, number or neutral
+	const (
+		neutral = iota
+		strongLeft
+		strongRight
+		number
+	)
+	classes := make([]int, len(graphemes))
+	for i, grapheme := range graphemes {
+		switch bidiClass(grapheme.Runes[0]) {
+		case bidi.L:
+			classes[i] = strongLeft
+		case bidi.R, bidi.AL:
+			classes[i] = strongRight
+		case bidi.EN, bidi.AN:
+			classes[i] = number
+		}
+	}
+
+	// levelFor gets the level of a character with a resolved strong direction
+	levelFor := func(class int) int {
+		if class == strongRight {
+			return 1
+		}
+		if baseLevel == 1 {
+			return 2
+		}
+		return 0
+	}
+
+	levels := make([]int, len(graphemes))
+	previousStrong := strongLeft
+	if base == RightToLeft {
+		previousStrong = strongRight
+	}
+	for i, class := range classes {
+		switch class {
+		case strongLeft, strongRight:
+			levels[i] = levelFor(class)
+			previousStrong = class
+		case number:
+			// Numbers are always displayed left-to-right
+			if previousStrong == strongRight || baseLevel == 1 {
+				levels[i] = 2
+			} else {
+				levels[i] = 0
+			}
+		default:
+			// Neutrals take the direction of the surrounding text when it agrees, otherwise the base direction
+			nextStrong := strongLeft
+			if base == RightToLeft {
+				nextStrong = strongRight
+			}
+			for _, next := range classes[i+1:] {
+				if next == strongLeft || next == strongRight {
+					nextStrong = next
+					break
+				}
+				if next == number && previousStrong == strongRight {
+					// Numbers act as right-to-left text when resolving neutrals
+					nextStrong = strongRight
+					break
+				}
+			}
+			if nextStrong == previousStrong {
+				levels[i] = levelFor(previousStrong)
+			} else {
+				levels[i] = baseLevel
+			}
+		}
+	}
+
+	return levels
+}
+
+// bidiClass ...
+// Gets the bidi class of a rune.
+func bidiClass(r rune) bidi.Class {
+	properties, _ := bidi.LookupRune(r)
+	return properties.Class()
+}
+
+// reverseGraphemes ...
+// Reverses a slice of graphemes in place.
+func reverseGraphemes(graphemes []Grapheme) {
+	for i, j := 0, len(graphemes)-1; i < j; i, j = i+1, j-1 {
+		graphemes[i], graphemes[j] = graphemes[j], graphemes[i]
+	}
+}
+
+// reverseInts ...
+// Reverses a slice of ints in place.
+func reverseInts(values []int) {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+}
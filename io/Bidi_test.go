@@ -0,0 +1,62 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "testing"
+
+func TestTextDirection(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Direction
+	}{
+		{"hello", LeftToRight},
+		{"שלום", RightToLeft},
+		{"سلام", RightToLeft},
+		{"123 שלום", RightToLeft},
+		{"(hello) שלום", LeftToRight},
+		{"", LeftToRight},
+	}
+	for _, test := range tests {
+		if direction := TextDirection(test.text); direction != test.expected {
+			t.Errorf("%q: expected direction %d, got %d", test.text, test.expected, direction)
+		}
+	}
+}
+
+func TestVisualText(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"hello", "hello"},
+		{"abc 123", "abc 123"},
+		{"שלום", "םולש"},
+		{"hello שלום world", "hello םולש world"},
+		{"שלום hello", "hello םולש"},
+		{"עמוד 12", "12 דומע"},
+		{"صفحة 3", "3 ةحفص"},
+		{"abc אבג 123 def", "abc 123 גבא def"},
+		{"(שלום)", "(םולש)"},
+		{"(hello)", "(hello)"},
+	}
+	for _, test := range tests {
+		if visual := VisualText(test.text); visual != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.text, test.expected, visual)
+		}
+	}
+}
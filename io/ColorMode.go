@@ -0,0 +1,294 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nsf/termbox-go"
+)
+
+// ColorMode ...
+// Typedef for the color capability of the terminal.
+type ColorMode int
+
+// Defines color modes, from least to most capable.
+const (
+	ColorModeMonochrome = ColorMode(iota) // No colors; bold, underline and reverse only
+	ColorMode8                            // The 8 basic ANSI colors
+	ColorMode16                           // The 8 basic ANSI colors plus bright variants
+	ColorMode256                          // The xterm 256-color palette
+	ColorModeTrueColor                    // 24-bit color
+)
+
+// Text attributes, combined with a color using bitwise OR
+const (
+	AttrBold      = int(termbox.AttrBold)
+	AttrUnderline = int(termbox.AttrUnderline)
+	AttrReverse   = int(termbox.AttrReverse)
+)
+
+// colorMask ...
+// The bits of a color value that hold the color (the remaining bits hold attributes).
+const colorMask = 0x1FF
+
+// colorModeNames ...
+// Names of the color modes, as used in configuration.
+var colorModeNames = map[ColorMode]string{
+	ColorModeMonochrome: "monochrome",
+	ColorMode8:          "8",
+	ColorMode16:         "16",
+	ColorMode256:        "256",
+	ColorModeTrueColor:  "truecolor",
+}
+
+// colorMode ...
+// The color mode that colors are mapped to before they are sent to the backend.
+var colorMode = ColorMode256
+
+// Colors that stand in for the terminal's default foreground and background colors (initially xterm's defaults)
+var (
+	defaultFgColor = 8 // Light grey
+	defaultBgColor = 1 // Black
+)
+
+// String ...
+// Gets the name of a color mode.
+func (m ColorMode) String() string {
+	return colorModeNames[m]
+}
+
+// NumColors ...
+// Gets the number of distinct colors available in a color mode.
+func (m ColorMode) NumColors() int {
+	switch m {
+	case ColorMode8:
+		return 8
+	case ColorMode16:
+		return 16
+	case ColorMode256, ColorModeTrueColor:
+		return 256
+	default:
+		return 0
+	}
+}
+
+// ParseColorMode ...
+// Parses the name of a color mode.
+func ParseColorMode(name string) (ColorMode, error) {
+	for mode, modeName := range colorModeNames {
+		if strings.EqualFold(name, modeName) {
+			return mode, nil
+		}
+	}
+
+	return ColorModeMonochrome, fmt.Errorf("unknown color mode %q", name)
+}
+
+// DetectColorMode ...
+// Detects the color capability of the terminal from the environment (NO_COLOR, COLORTERM and TERM).
+func DetectColorMode(getenv func(string) string) ColorMode {
+	// See https://no-color.org
+	if getenv("NO_COLOR") != "" {
+		return ColorModeMonochrome
+	}
+
+	colorTerm := strings.ToLower(getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return ColorModeTrueColor
+	}
+
+	term := strings.ToLower(getenv("TERM"))
+	switch {
+	case term == "" || term == "dumb" || strings.HasPrefix(term, "vt"):
+		return ColorModeMonochrome
+	case strings.Contains(term, "direct") || strings.Contains(term, "truecolor"):
+		return ColorModeTrueColor
+	case strings.Contains(term, "256color"):
+		return ColorMode256
+	case strings.Contains(term, "16color") || strings.HasPrefix(term, "xterm") || strings.HasPrefix(term, "rxvt"):
+		return ColorMode16
+	default:
+		// The Linux console and other basic terminals
+		return ColorMode8
+	}
+}
+
+// SetColorMode ...
+// Sets the color mode that colors are mapped to, and repaints.
+func SetColorMode(mode ColorMode) {
+	colorMode = mode
+	backend.SetColorMode(mode)
+	Repaint()
+}
+
+// SetDefaultColors ...
+// Sets the colors that stand in for the terminal's default foreground and background colors where the default
+// cannot be combined with attributes (in truecolor mode), and repaints. Zero keeps the current stand-in.
+func SetDefaultColors(fgColor int, bgColor int) {
+	if fgColor&colorMask != 0 {
+		defaultFgColor = fgColor & colorMask
+	}
+	if bgColor&colorMask != 0 {
+		defaultBgColor = bgColor & colorMask
+	}
+	Repaint()
+}
+
+// GetColorMode ...
+// Gets the color mode that colors are mapped to.
+func GetColorMode() ColorMode {
+	return colorMode
+}
+
+// MapColors ...
+// Maps a foreground and background color from the 256-color palette to the nearest available in a color mode.
+// Colors are 256-color codes as used by the renderer (0 is the default color, otherwise the palette index plus one).
+// In monochrome mode, colored text is shown bold (bright colors) or underlined (dark colors), and colored
+// backgrounds are shown in reverse video. Attributes combined with the foreground color are kept.
+func MapColors(fgColor int, bgColor int, mode ColorMode) (int, int) {
+	attrs := fgColor &^ colorMask
+	fgColor &= colorMask
+	bgColor &= colorMask
+
+	switch mode {
+	case ColorMode256, ColorModeTrueColor:
+		return fgColor | attrs, bgColor
+	case ColorMode16:
+		return nearestColor(fgColor, 16) | attrs, nearestColor(bgColor, 16)
+	case ColorMode8:
+		return nearestColor(fgColor, 8) | attrs, nearestColor(bgColor, 8)
+	}
+
+	// Monochrome
+	if bgColor != 0 && luminance(bgColor) > 0 {
+		attrs |= AttrReverse
+	}
+	if fgColor != 0 && isChromatic(fgColor) {
+		if brightness(fgColor) >= 192 {
+			attrs |= AttrBold
+		} else {
+			attrs |= AttrUnderline
+		}
+	}
+
+	return attrs, 0
+}
+
+// ColorToRGB ...
+// Gets the red, green and blue components of a color from the 256-color palette.
+func ColorToRGB(color int) (r uint8, g uint8, b uint8) {
+	index := color - 1
+	switch {
+	case index < 0:
+		return 0, 0, 0
+	case index < 16:
+		rgb := systemColors[index]
+		return rgb[0], rgb[1], rgb[2]
+	case index < 232:
+		// 6x6x6 color cube
+		index -= 16
+		return cubeLevels[index/36], cubeLevels[(index/6)%6], cubeLevels[index%6]
+	default:
+		// Grayscale ramp
+		grey := uint8(8 + 10*(index-232))
+		return grey, grey, grey
+	}
+}
+
+// systemColors ...
+// The xterm default values of the 16 system colors.
+var systemColors = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels ...
+// The intensity of each step of the 6x6x6 color cube.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// nearestColor ...
+// Gets the closest of the first n system colors to a palette color.
+func nearestColor(color int, n int) int {
+	if color == 0 {
+		return 0
+	}
+
+	r, g, b := ColorToRGB(color)
+	nearest := 0
+	nearestDistance := -1
+	for i := 0; i < n; i++ {
+		sr, sg, sb := ColorToRGB(i + 1)
+		distance := square(int(r)-int(sr)) + square(int(g)-int(sg)) + square(int(b)-int(sb))
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearest = i
+			nearestDistance = distance
+		}
+	}
+
+	return nearest + 1
+}
+
+// luminance ...
+// Gets the approximate perceived brightness (0-255) of a palette color.
+func luminance(color int) int {
+	r, g, b := ColorToRGB(color)
+	return (299*int(r) + 587*int(g) + 114*int(b)) / 1000
+}
+
+// brightness ...
+// Gets the brightness (0-255) of a palette color, which is the value of its strongest component.
+func brightness(color int) int {
+	r, g, b := ColorToRGB(color)
+	return maxInt(int(r), maxInt(int(g), int(b)))
+}
+
+// isChromatic ...
+// Determines whether a palette color has a noticeable hue (rather than being black, white or grey).
+func isChromatic(color int) bool {
+	r, g, b := ColorToRGB(color)
+	max := maxInt(int(r), maxInt(int(g), int(b)))
+	min := minInt(int(r), minInt(int(g), int(b)))
+
+	return max-min > 32
+}
+
+// square ...
+// Squares an integer.
+func square(x int) int {
+	return x * x
+}
+
+// maxInt ...
+// Gets the larger of two integers.
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// minInt ...
+// Gets the smaller of two integers.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "testing"
+
+func TestDetectColorMode(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected ColorMode
+	}{
+		{map[string]string{"TERM": "xterm-256color"}, ColorMode256},
+		{map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, ColorModeTrueColor},
+		{map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, ColorModeMonochrome},
+		{map[string]string{"TERM": "xterm"}, ColorMode16},
+		{map[string]string{"TERM": "linux"}, ColorMode8},
+		{map[string]string{"TERM": "dumb"}, ColorModeMonochrome},
+		{map[string]string{}, ColorModeMonochrome},
+	}
+	for _, test := range tests {
+		getenv := func(name string) string { return test.env[name] }
+		if mode := DetectColorMode(getenv); mode != test.expected {
+			t.Errorf("%v: expected %s, got %s", test.env, test.expected, mode)
+		}
+	}
+}
+
+func TestParseColorMode(t *testing.T) {
+	for _, mode := range []ColorMode{ColorModeMonochrome, ColorMode8, ColorMode16, ColorMode256, ColorModeTrueColor} {
+		parsed, err := ParseColorMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("Expected %s to parse, got %s (%v)", mode, parsed, err)
+		}
+	}
+	if _, err := ParseColorMode("rainbow"); err == nil {
+		t.Error("Expected an error for an unknown color mode")
+	}
+}
+
+func TestMapColors(t *testing.T) {
+	tests := []struct {
+		mode       ColorMode
+		fgColor    int
+		bgColor    int
+		expectedFg int
+		expectedBg int
+	}{
+		{ColorMode256, 197, 238, 197, 238},
+		{ColorModeTrueColor, 197, 238, 197, 238},
+		{ColorMode16, 197, 0, 10, 0},                              // Bright red
+		{ColorMode8, 197, 238, 2, 1},                              // Red on black
+		{ColorMode8, 0, 0, 0, 0},                                  // Default colors stay default
+		{ColorMode16, 255 | AttrBold, 0, 8 | AttrBold, 0},         // Attributes are kept
+		{ColorModeMonochrome, 197, 0, AttrBold, 0},                // Bright color
+		{ColorModeMonochrome, 19, 0, AttrUnderline, 0},            // Dark color
+		{ColorModeMonochrome, 255, 238, AttrReverse, 0},           // Grey text on a highlight
+		{ColorModeMonochrome, AttrUnderline, 0, AttrUnderline, 0}, // Attribute only
+		{ColorModeMonochrome, 1, 1, 0, 0},                         // Black
+	}
+	for _, test := range tests {
+		fg, bg := MapColors(test.fgColor, test.bgColor, test.mode)
+		if fg != test.expectedFg || bg != test.expectedBg {
+			t.Errorf("%s: expected %d/%d to map to %d/%d, got %d/%d", test.mode, test.fgColor, test.bgColor, test.expectedFg, test.expectedBg, fg, bg)
+		}
+	}
+}
+
+func TestColorIsMappedWhenPushed(t *testing.T) {
+	b := NewMemoryBackend(5, 1)
+	SetBackend(b)
+	defer SetColorMode(ColorMode256)
+
+	SetColorMode(ColorMode8)
+	RenderText("a", 0, 0, 197, 0)
+	Flush()
+	if cell := b.GetCell(0, 0); cell.FgColor != 2 {
+		t.Errorf("Expected color to be mapped to red, got %d", cell.FgColor)
+	}
+	if b.GetColorMode() != ColorMode8 {
+		t.Error("Expected the backend to be told the color mode")
+	}
+}
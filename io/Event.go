@@ -0,0 +1,40 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+// EventType ...
+// Typedef for event types.
+type EventType int
+
+// Defines event types.
+const (
+	EventNone = EventType(iota)
+	EventKey
+	EventResize
+	EventInterrupt // Sent by Interrupt to wake a blocked PollEvent
+)
+
+// Event ...
+// Represents an input event from the backend.
+type Event struct {
+	Type   EventType
+	Ch     rune // The character typed (zero for special keys)
+	Key    Key  // The special key pressed
+	Width  int  // The new width (resize events)
+	Height int  // The new height (resize events)
+}
@@ -17,46 +17,224 @@
 
 package io
 
-import termbox "github.com/nsf/termbox-go"
+import (
+	"sync"
+	"time"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+// Key ...
+// Typedef for special (non-character) keys.
+type Key uint16
+
+// Defines special keys.
+const (
+	KeyEnter      = Key(termbox.KeyEnter)
+	KeyEsc        = Key(termbox.KeyEsc)
+	KeyArrowUp    = Key(termbox.KeyArrowUp)
+	KeyArrowDown  = Key(termbox.KeyArrowDown)
+	KeyArrowLeft  = Key(termbox.KeyArrowLeft)
+	KeyArrowRight = Key(termbox.KeyArrowRight)
+	KeyBackspace  = Key(termbox.KeyBackspace)
+	KeyBackspace2 = Key(termbox.KeyBackspace2)
+	KeySpace      = Key(termbox.KeySpace)
+)
+
+// callbackQueueSize ...
+// The number of callbacks that can be posted to the event loop before Post blocks.
+const callbackQueueSize = 64
 
 // EventListener ...
+// Runs the event loop. Terminal events, timers, posted callbacks and background task completions are all
+// handled on the goroutine that calls WaitForEvent, so handlers never run concurrently.
 type EventListener struct {
 	keypressHandlers map[rune]func()
+	keyHandlers      map[Key]func()
 	resizeHandler    func()
+	textInputHandler func(ch rune)    // Receives typed characters while text input is captured
+	now              func() time.Time // Gets the current time (used to decide when timers are due)
+	timers           []*Timer         // Scheduled timers
+	events           chan Event       // Terminal events read by the polling goroutine
+	callbacks        chan func()      // Callbacks posted to run on the event loop
+	stop             chan struct{}    // Closed when the event loop stops
+	polledBackend    Backend          // The backend the polling goroutine reads events from
+	polling          sync.Once        // Starts the polling goroutine
+	stopping         sync.Once        // Stops the event loop
+	pollerDone       sync.WaitGroup   // Waits for the polling goroutine to exit
 }
 
 // NewEventListener ...
 // Constructs a new event listener.
 func NewEventListener(resizeHandler func()) *EventListener {
-	eventListener := &EventListener{resizeHandler: resizeHandler}
+	eventListener := &EventListener{resizeHandler: resizeHandler, now: time.Now}
 	eventListener.keypressHandlers = make(map[rune]func())
+	eventListener.keyHandlers = make(map[Key]func())
+	eventListener.events = make(chan Event)
+	eventListener.callbacks = make(chan func(), callbackQueueSize)
+	eventListener.stop = make(chan struct{})
 
 	return eventListener
 }
 
+// SetClock ...
+// Sets the function used to get the current time when deciding which timers are due.
+func (e *EventListener) SetClock(now func() time.Time) {
+	e.now = now
+}
+
 // RegisterKeypressHandler ...
 // Registers a new keypress handler.
 func (e *EventListener) RegisterKeypressHandler(key rune, handler func()) {
 	e.keypressHandlers[key] = handler
 }
 
+// RegisterKeyHandler ...
+// Registers a new handler for a special key.
+func (e *EventListener) RegisterKeyHandler(key Key, handler func()) {
+	e.keyHandlers[key] = handler
+}
+
+// CaptureTextInput ...
+// Sends typed characters to a handler instead of the keypress handlers (e.g. while the user types into a field).
+// Special keys are still sent to their handlers.
+func (e *EventListener) CaptureTextInput(handler func(ch rune)) {
+	e.textInputHandler = handler
+}
+
+// ReleaseTextInput ...
+// Stops capturing text input, so typed characters are sent to the keypress handlers again.
+func (e *EventListener) ReleaseTextInput() {
+	e.textInputHandler = nil
+}
+
+// IsCapturingTextInput ...
+// Determines whether typed characters are being captured.
+func (e *EventListener) IsCapturingTextInput() bool {
+	return e.textInputHandler != nil
+}
+
+// Post ...
+// Runs a callback on the event loop. Safe to call from any goroutine. Callbacks posted after the event
+// loop stops are dropped.
+func (e *EventListener) Post(callback func()) {
+	select {
+	case <-e.stop:
+		return
+	default:
+	}
+	select {
+	case e.callbacks <- callback:
+	case <-e.stop:
+	}
+}
+
+// RunInBackground ...
+// Runs a task on a new goroutine, then runs done on the event loop once the task completes.
+func (e *EventListener) RunInBackground(task func(), done func()) {
+	go func() {
+		task()
+		e.Post(done)
+	}()
+}
+
+// Stop ...
+// Stops the event loop: stops polling for terminal events (waiting for the polling goroutine to exit),
+// cancels timers and drops callbacks posted from now on. Call from the event loop, before closing the backend.
+func (e *EventListener) Stop() {
+	e.stopping.Do(func() {
+		close(e.stop)
+		e.timers = nil
+		e.polling.Do(func() {}) // Polling never starts after stopping
+		if e.polledBackend != nil {
+			e.polledBackend.Interrupt()
+		}
+		e.pollerDone.Wait()
+	})
+}
+
 // WaitForEvent ...
-// Waits for user input.
+// Waits for and handles the next terminal event, due timer or posted callback.
 func (e *EventListener) WaitForEvent() {
-	// Block and wait for input
-	event := termbox.PollEvent()
+	e.polling.Do(e.startPolling)
+
+	// Timers that are already due are handled first
+	if e.FireDueTimers() > 0 {
+		return
+	}
+
+	// Wait (until the next timer is due, if there is one)
+	var timeout <-chan time.Time
+	if deadline, ok := e.nextDeadline(); ok {
+		timer := time.NewTimer(deadline.Sub(e.now()))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case event := <-e.events:
+		e.handleEvent(event)
+	case callback := <-e.callbacks:
+		callback()
+	case <-timeout:
+		e.FireDueTimers()
+	case <-e.stop:
+	}
+}
+
+// startPolling ...
+// Starts a goroutine that reads terminal events from the backend and sends them to the event loop.
+func (e *EventListener) startPolling() {
+	b := backend
+	e.polledBackend = b
+	e.pollerDone.Add(1)
+	go func() {
+		defer e.pollerDone.Done()
+		for {
+			event := b.PollEvent()
+			if event.Type == EventInterrupt {
+				select {
+				case <-e.stop:
+					return
+				default:
+					continue
+				}
+			}
+			select {
+			case e.events <- event:
+			case <-e.stop:
+				return
+			}
+		}
+	}()
+}
+
+// handleEvent ...
+// Sends a terminal event to its handlers.
+func (e *EventListener) handleEvent(event Event) {
+	// Send typed characters to the text input handler while it is captured
+	if event.Type == EventKey && e.textInputHandler != nil && (event.Ch != 0 || event.Key == KeySpace) {
+		if event.Ch == 0 {
+			event.Ch = ' '
+		}
+		e.textInputHandler(event.Ch)
+		return
+	}
 
 	// Handle keypress
-	if event.Type == termbox.EventKey {
+	if event.Type == EventKey {
 		for key, value := range e.keypressHandlers {
 			if event.Ch == key {
 				value()
 			}
 		}
+		// Special keys have no character
+		if handler, ok := e.keyHandlers[event.Key]; ok && event.Ch == 0 {
+			handler()
+		}
 	}
 
 	// Handle resize
-	if event.Type == termbox.EventResize {
+	if event.Type == EventResize {
 		e.resizeHandler()
 	}
 }
@@ -0,0 +1,137 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestEventListener ...
+// Creates an event listener reading from a memory backend, with a fake clock that the returned function
+// moves forward.
+func newTestEventListener(t *testing.T) (*EventListener, *MemoryBackend, func(time.Duration)) {
+	b := NewMemoryBackend(10, 5)
+	SetBackend(b)
+	now := time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC)
+	e := NewEventListener(func() {})
+	e.SetClock(func() time.Time { return now })
+	t.Cleanup(e.Stop)
+
+	return e, b, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestEventListenerHandlesKeys(t *testing.T) {
+	e, b, _ := newTestEventListener(t)
+	pressed := ""
+	e.RegisterKeypressHandler('a', func() { pressed += "a" })
+	e.RegisterKeyHandler(KeyEnter, func() { pressed += "⏎" })
+
+	b.QueueEvent(Event{Type: EventKey, Ch: 'a'})
+	b.QueueEvent(Event{Type: EventKey, Key: KeyEnter})
+	e.WaitForEvent()
+	e.WaitForEvent()
+
+	if pressed != "a⏎" {
+		t.Errorf("Expected handlers to run in order, got %q", pressed)
+	}
+}
+
+func TestEventListenerTimers(t *testing.T) {
+	e, _, advance := newTestEventListener(t)
+	var fired []string
+	e.Schedule(2*time.Second, func() { fired = append(fired, "once") })
+	tick := e.Every(time.Second, func() { fired = append(fired, "tick") })
+	cancelled := e.Schedule(time.Second, func() { fired = append(fired, "cancelled") })
+	cancelled.Cancel()
+
+	if n := e.FireDueTimers(); n != 0 {
+		t.Errorf("Expected no timers to be due, %d ran", n)
+	}
+	advance(time.Second)
+	e.FireDueTimers()
+	advance(time.Second)
+	e.FireDueTimers()
+	// Falling behind runs a repeating timer once
+	advance(10 * time.Second)
+	e.FireDueTimers()
+	tick.Cancel()
+	advance(time.Minute)
+	e.FireDueTimers()
+
+	expected := []string{"tick", "once", "tick", "tick"}
+	if len(fired) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, fired)
+	}
+	for i := range expected {
+		if fired[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, fired)
+		}
+	}
+}
+
+func TestEventListenerWaitsForTimers(t *testing.T) {
+	b := NewMemoryBackend(10, 5)
+	SetBackend(b)
+	e := NewEventListener(func() {})
+	defer e.Stop()
+
+	fired := false
+	e.Schedule(10*time.Millisecond, func() { fired = true })
+	e.WaitForEvent()
+
+	if !fired {
+		t.Error("Expected WaitForEvent to return after running the timer")
+	}
+}
+
+func TestEventListenerRunsBackgroundTasks(t *testing.T) {
+	e, _, _ := newTestEventListener(t)
+	result := 0
+	completed := false
+	e.RunInBackground(func() { result = 42 }, func() { completed = true })
+	e.WaitForEvent()
+
+	if !completed || result != 42 {
+		t.Errorf("Expected the task to complete on the event loop (completed %v, result %d)", completed, result)
+	}
+}
+
+func TestEventListenerStop(t *testing.T) {
+	e, b, _ := newTestEventListener(t)
+	e.Schedule(time.Hour, func() { t.Error("Expected timers to be cancelled") })
+	b.QueueEvent(Event{Type: EventKey, Ch: 'a'})
+	e.WaitForEvent() // Starts polling
+
+	done := make(chan struct{})
+	go func() {
+		e.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to return")
+	}
+
+	e.Post(func() { t.Error("Expected callbacks posted after stopping to be dropped") })
+	e.WaitForEvent()
+	if n := e.FireDueTimers(); n != 0 {
+		t.Errorf("Expected no timers after stopping, %d ran", n)
+	}
+}
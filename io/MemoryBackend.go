@@ -0,0 +1,208 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"fmt"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// Cell ...
+// Represents the contents of a single cell.
+type Cell struct {
+	Ch      rune
+	FgColor int
+	BgColor int
+}
+
+// memoryBackendEventQueueSize ...
+// The number of events that can be queued on a memory backend.
+const memoryBackendEventQueueSize = 256
+
+// MemoryBackend ...
+// Renders to an in-memory buffer of cells. Used for testing without a terminal.
+type MemoryBackend struct {
+	width  int
+	height int
+	cells  []Cell
+	events chan Event // Queued events, returned by PollEvent
+	mode   ColorMode
+}
+
+// NewMemoryBackend ...
+// Creates a new in-memory backend of the specified size.
+func NewMemoryBackend(width int, height int) *MemoryBackend {
+	b := &MemoryBackend{events: make(chan Event, memoryBackendEventQueueSize)}
+	b.Resize(width, height)
+
+	return b
+}
+
+// Init ...
+// Initializes the backend (nothing to do for memory).
+func (b *MemoryBackend) Init() error {
+	return nil
+}
+
+// Close ...
+// Closes the backend (nothing to do for memory).
+func (b *MemoryBackend) Close() {
+}
+
+// SetCell ...
+// Sets the contents of a cell. Cells outside the buffer are ignored.
+func (b *MemoryBackend) SetCell(x int, y int, ch rune, fgColor int, bgColor int) {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return
+	}
+	b.cells[y*b.width+x] = Cell{Ch: ch, FgColor: fgColor, BgColor: bgColor}
+}
+
+// Clear ...
+// Clears the buffer using the specified background color.
+func (b *MemoryBackend) Clear(bgColor int) {
+	for i := range b.cells {
+		b.cells[i] = Cell{Ch: ' ', BgColor: bgColor}
+	}
+}
+
+// Flush ...
+// Flushes changes (nothing to do for memory).
+func (b *MemoryBackend) Flush() {
+}
+
+// Size ...
+// Gets the dimensions of the buffer.
+func (b *MemoryBackend) Size() (width int, height int) {
+	return b.width, b.height
+}
+
+// PollEvent ...
+// Blocks until the next queued event.
+func (b *MemoryBackend) PollEvent() Event {
+	return <-b.events
+}
+
+// Interrupt ...
+// Wakes a blocked PollEvent, which returns an interrupt event.
+func (b *MemoryBackend) Interrupt() {
+	b.QueueEvent(Event{Type: EventInterrupt})
+}
+
+// QueueEvent ...
+// Queues an event to be returned by PollEvent.
+func (b *MemoryBackend) QueueEvent(event Event) {
+	b.events <- event
+}
+
+// SetColorMode ...
+// Records the color mode (colors are stored exactly as they are set).
+func (b *MemoryBackend) SetColorMode(mode ColorMode) {
+	b.mode = mode
+}
+
+// GetColorMode ...
+// Gets the color mode last set.
+func (b *MemoryBackend) GetColorMode() ColorMode {
+	return b.mode
+}
+
+// Resize ...
+// Resizes the buffer, clearing its contents.
+func (b *MemoryBackend) Resize(width int, height int) {
+	b.width = width
+	b.height = height
+	b.cells = make([]Cell, width*height)
+	b.Clear(0)
+}
+
+// GetCell ...
+// Gets the contents of a cell.
+func (b *MemoryBackend) GetCell(x int, y int) Cell {
+	return b.cells[y*b.width+x]
+}
+
+// String ...
+// Gets the text content of the buffer, one line per row, as it would appear in a terminal.
+func (b *MemoryBackend) String() string {
+	var sb strings.Builder
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			ch := b.GetCell(x, y).Ch
+			sb.WriteRune(ch)
+			// Wide runes cover the following cell
+			if runewidth.RuneWidth(ch) == 2 {
+				x++
+			}
+		}
+		sb.WriteRune('\n')
+	}
+
+	return sb.String()
+}
+
+// Colors ...
+// Describes the colors of the buffer, one line per row that has cells not in the default colors, as runs of
+// columns with the same colors and attributes (e.g. "3: 2-10 fg 255+bold bg 238").
+func (b *MemoryBackend) Colors() string {
+	var sb strings.Builder
+	for y := 0; y < b.height; y++ {
+		var runs []string
+		for x := 0; x < b.width; {
+			cell := b.GetCell(x, y)
+			end := x
+			for end+1 < b.width && b.GetCell(end+1, y).FgColor == cell.FgColor && b.GetCell(end+1, y).BgColor == cell.BgColor {
+				end++
+			}
+			if cell.FgColor != 0 || cell.BgColor != 0 {
+				columns := fmt.Sprint(x)
+				if end > x {
+					columns = fmt.Sprintf("%d-%d", x, end)
+				}
+				runs = append(runs, columns+" "+describeColors(cell.FgColor, cell.BgColor))
+			}
+			x = end + 1
+		}
+		if len(runs) > 0 {
+			sb.WriteString(fmt.Sprintf("%d: %s\n", y, strings.Join(runs, ", ")))
+		}
+	}
+
+	return sb.String()
+}
+
+// describeColors ...
+// Describes a foreground and background color, including any attributes combined with the foreground.
+func describeColors(fgColor int, bgColor int) string {
+	description := fmt.Sprintf("fg %d", fgColor&^(AttrBold|AttrUnderline|AttrReverse))
+	for _, attr := range []struct {
+		attr int
+		name string
+	}{{AttrBold, "bold"}, {AttrUnderline, "underline"}, {AttrReverse, "reverse"}} {
+		if fgColor&attr.attr != 0 {
+			description += "+" + attr.name
+		}
+	}
+	if bgColor != 0 {
+		description += fmt.Sprintf(" bg %d", bgColor)
+	}
+
+	return description
+}
@@ -17,11 +17,7 @@
 
 package io
 
-import (
-	"unicode/utf8"
-
-	"github.com/nsf/termbox-go"
-)
+import runewidth "github.com/mattn/go-runewidth"
 
 // Characters for rendering the border runes
 const (
@@ -36,9 +32,10 @@ const (
 // ClearArea ...
 // Clears an area to the specified background color.
 func ClearArea(x int, y int, width int, height int, bgColor int) {
+	buffer.ensureSize()
 	for ix := 0; ix < width; ix++ {
 		for iy := 0; iy < height; iy++ {
-			termbox.SetCell(x+ix, y+iy, ' ', 0, termbox.Attribute(bgColor))
+			buffer.setCell(x+ix, y+iy, ' ', 0, bgColor)
 		}
 	}
 }
@@ -46,65 +43,81 @@ func ClearArea(x int, y int, width int, height int, bgColor int) {
 // ClearScreen ...
 // Clears the screen using a specified color.
 func ClearScreen(bgColor int) {
-	termbox.Clear(0, termbox.Attribute(bgColor))
+	buffer.ensureSize()
+	buffer.clear(bgColor)
 }
 
 // Flush ...
-// Flush render commands.
+// Flush render commands. Only cells that changed since the last flush are sent to the backend.
 func Flush() {
-	termbox.Flush()
+	buffer.ensureSize()
+	buffer.flush(false)
+}
+
+// Repaint ...
+// Sends every cell to the backend on the next flush (e.g. after the terminal was disturbed by another program).
+func Repaint() {
+	buffer.ensureSize()
+	buffer.invalidate()
 }
 
 // GetWindowSize ...
 // Gets the current dimensions of the terminal.
 func GetWindowSize() (width int, height int) {
-	return termbox.Size()
+	return backend.Size()
 }
 
 // RenderPaneBorder ...
 // Renders a border for a window pane.
 func RenderPaneBorder(x int, y int, width int, height int, fgColor int, bgColor int) {
 	// Render the corners
-	termbox.SetCell(x, y, BorderRuneCornerTopLeft, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
-	termbox.SetCell(x+width, y, BorderRuneCornerTopRight, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
-	termbox.SetCell(x, y+height, BorderRuneCornerBottomLeft, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
-	termbox.SetCell(x+width, y+height, BorderRuneCornerBottomRight, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
+	buffer.setCell(x, y, BorderRuneCornerTopLeft, fgColor, bgColor)
+	buffer.setCell(x+width, y, BorderRuneCornerTopRight, fgColor, bgColor)
+	buffer.setCell(x, y+height, BorderRuneCornerBottomLeft, fgColor, bgColor)
+	buffer.setCell(x+width, y+height, BorderRuneCornerBottomRight, fgColor, bgColor)
 	// Render top border
 	for ix := 1; ix < width; ix++ {
-		termbox.SetCell(x+ix, y, BorderRuneHorizontal, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
+		buffer.setCell(x+ix, y, BorderRuneHorizontal, fgColor, bgColor)
 	}
 	// Render bottom border
 	for ix := 1; ix < width; ix++ {
-		termbox.SetCell(x+ix, y+height, BorderRuneHorizontal, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
+		buffer.setCell(x+ix, y+height, BorderRuneHorizontal, fgColor, bgColor)
 	}
 	// Render left border
 	for iy := 1; iy < height; iy++ {
-		termbox.SetCell(x, y+iy, BorderRuneVertical, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
+		buffer.setCell(x, y+iy, BorderRuneVertical, fgColor, bgColor)
 	}
 	// Render right border
 	for iy := 1; iy < height; iy++ {
-		termbox.SetCell(x+width, y+iy, BorderRuneVertical, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
+		buffer.setCell(x+width, y+iy, BorderRuneVertical, fgColor, bgColor)
 	}
 }
 
 // RenderText ...
 // Renders a string at specific coordinates using the supplied colors.
+// A terminal cell holds a single rune, so combining marks are composed with the rune before them where Unicode
+// has a precomposed form (e.g. "e" and U+0301 render as "é"). Marks that cannot be composed are not rendered,
+// leaving the base rune (e.g. "ɔ̃" renders as "ɔ").
 func RenderText(text string, x int, y int, fgColor int, bgColor int) {
-	// Get the text to render as a byte array
-	bytes := []byte(text)
+	// Shape and reorder right-to-left text for display
+	text = prepareText(text)
 
 	// Initialize terminal column index
 	colIx := x
 
-	// Loop through the bytes in the slice
-	for bIx := 0; bIx < len(bytes); {
-		// Decode the next rune in the string
-		r, size := utf8.DecodeRune(bytes[bIx:])
-		// Set the cell value to that of the decoded rune
-		termbox.SetCell(colIx, y, r, termbox.Attribute(fgColor), termbox.Attribute(bgColor))
-		// Advance the index by the size of the rune just decoded (some runes use multiple bytes)
-		bIx += size
-		// Increment the terminal column index (one column per rune rendered)
-		colIx++
+	// Loop through the grapheme clusters (base rune plus combining runes) in the string
+	for _, grapheme := range Graphemes(text) {
+		// Skip clusters that take up no space (control characters)
+		if grapheme.Width == 0 {
+			continue
+		}
+		// Set the cell value to that of the base rune (the cluster is already composed where possible)
+		buffer.setCell(colIx, y, grapheme.Runes[0], fgColor, bgColor)
+		// Pad emoji sequences whose base rune is narrow so following text stays aligned
+		if grapheme.Width > runewidth.RuneWidth(grapheme.Runes[0]) {
+			buffer.setCell(colIx+1, y, ' ', fgColor, bgColor)
+		}
+		// Advance the terminal column index by the display width of the cluster (wide characters use two columns)
+		colIx += grapheme.Width
 	}
 }
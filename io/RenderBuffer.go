@@ -0,0 +1,144 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+// blankCell ...
+// An empty cell on the default background.
+var blankCell = Cell{Ch: ' '}
+
+// unknownCell ...
+// Marks a cell whose contents on the backend are unknown, so that it is always pushed.
+var unknownCell = Cell{Ch: -1}
+
+// renderBuffer ...
+// Retains the cells that have been pushed to the backend (the front buffer) and the cells rendered since (the
+// back buffer). Rendering only writes to the back buffer; flushing pushes just the cells that changed.
+type renderBuffer struct {
+	width   int
+	height  int
+	front   []Cell // Cells as last pushed to the backend
+	back    []Cell // Cells as rendered since the last flush
+	dirty   []int  // Indexes of back buffer cells written since the last flush
+	isDirty []bool // Whether each cell is in the dirty list
+}
+
+// buffer ...
+// The render buffer in front of the backend.
+var buffer = &renderBuffer{}
+
+// ensureSize ...
+// Matches the buffer to the size of the backend. When the size changes, the backend is cleared and the buffer
+// starts again from blank.
+func (r *renderBuffer) ensureSize() {
+	width, height := backend.Size()
+	if width == r.width && height == r.height && r.back != nil {
+		return
+	}
+
+	r.width = width
+	r.height = height
+	r.front = make([]Cell, width*height)
+	r.back = make([]Cell, width*height)
+	r.isDirty = make([]bool, width*height)
+	r.dirty = r.dirty[:0]
+	for i := range r.back {
+		r.front[i] = blankCell
+		r.back[i] = blankCell
+	}
+	backend.Clear(0)
+}
+
+// invalidate ...
+// Forgets what the backend is showing so that the next flush pushes every cell.
+func (r *renderBuffer) invalidate() {
+	for i := range r.front {
+		r.front[i] = unknownCell
+		r.markDirty(i)
+	}
+}
+
+// setCell ...
+// Sets the contents of a cell in the back buffer. Cells outside the buffer are ignored.
+func (r *renderBuffer) setCell(x int, y int, ch rune, fgColor int, bgColor int) {
+	if r.back == nil {
+		r.ensureSize()
+	}
+	if x < 0 || y < 0 || x >= r.width || y >= r.height {
+		return
+	}
+	i := y*r.width + x
+	r.back[i] = Cell{Ch: ch, FgColor: fgColor, BgColor: bgColor}
+	r.markDirty(i)
+}
+
+// markDirty ...
+// Adds a cell to the list of cells to compare on the next flush.
+func (r *renderBuffer) markDirty(i int) {
+	if !r.isDirty[i] {
+		r.isDirty[i] = true
+		r.dirty = append(r.dirty, i)
+	}
+}
+
+// clear ...
+// Clears the back buffer to the specified background color.
+func (r *renderBuffer) clear(bgColor int) {
+	for i := range r.back {
+		r.back[i] = Cell{Ch: ' ', BgColor: bgColor}
+		r.markDirty(i)
+	}
+}
+
+// flush ...
+// Pushes cells that differ from what the backend is showing (or every cell if full is set) and flushes the backend.
+// Returns the number of cells pushed.
+func (r *renderBuffer) flush(full bool) int {
+	pushed := 0
+	if full {
+		for i := range r.back {
+			r.push(i)
+			pushed++
+		}
+	} else {
+		for _, i := range r.dirty {
+			if r.back[i] != r.front[i] {
+				r.push(i)
+				pushed++
+			}
+		}
+	}
+
+	// Reset dirty tracking
+	for _, i := range r.dirty {
+		r.isDirty[i] = false
+	}
+	r.dirty = r.dirty[:0]
+
+	backend.Flush()
+
+	return pushed
+}
+
+// push ...
+// Pushes a single cell to the backend.
+func (r *renderBuffer) push(i int) {
+	cell := r.back[i]
+	fgColor, bgColor := MapColors(cell.FgColor, cell.BgColor, colorMode)
+	backend.SetCell(i%r.width, i/r.width, cell.Ch, fgColor, bgColor)
+	r.front[i] = cell
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"fmt"
+	"testing"
+)
+
+// countingBackend ...
+// A memory backend that counts the cells pushed to it.
+type countingBackend struct {
+	*MemoryBackend
+	cellsSet int
+}
+
+// SetCell ...
+// Counts and sets a cell.
+func (b *countingBackend) SetCell(x int, y int, ch rune, fgColor int, bgColor int) {
+	b.cellsSet++
+	b.MemoryBackend.SetCell(x, y, ch, fgColor, bgColor)
+}
+
+// newCountingBackend ...
+// Creates a counting backend and makes it the current backend.
+func newCountingBackend(width int, height int) *countingBackend {
+	b := &countingBackend{MemoryBackend: NewMemoryBackend(width, height)}
+	SetBackend(b)
+
+	return b
+}
+
+// renderList ...
+// Renders a bordered list of words with one row highlighted, the way a list screen does on every frame.
+func renderList(width int, height int, selected int) {
+	ClearArea(0, 0, width, height, 0)
+	RenderPaneBorder(0, 0, width-1, height-1, 5, 0)
+	for row := 1; row < height-1; row++ {
+		bgColor := 0
+		if row == selected {
+			bgColor = 238
+		}
+		RenderText(fmt.Sprintf("[%d] word number %d (in 10 languages)", row, row), 2, row, 255, bgColor)
+	}
+}
+
+func TestFlushPushesOnlyChangedCells(t *testing.T) {
+	b := newCountingBackend(20, 3)
+
+	RenderText("hello", 0, 0, 255, 0)
+	Flush()
+	if b.cellsSet != 5 {
+		t.Errorf("Expected 5 cells pushed on first flush, got %d", b.cellsSet)
+	}
+
+	// Re-rendering the same content pushes nothing
+	b.cellsSet = 0
+	ClearScreen(0)
+	RenderText("hello", 0, 0, 255, 0)
+	Flush()
+	if b.cellsSet != 0 {
+		t.Errorf("Expected no cells pushed for unchanged content, got %d", b.cellsSet)
+	}
+
+	// Changing one character pushes one cell
+	RenderText("hallo", 0, 0, 255, 0)
+	Flush()
+	if b.cellsSet != 1 {
+		t.Errorf("Expected 1 cell pushed, got %d", b.cellsSet)
+	}
+	if got := b.String(); got != "hallo               \n                    \n                    \n" {
+		t.Errorf("Unexpected backend content:\n%s", got)
+	}
+}
+
+func TestResizeRepaintsEverything(t *testing.T) {
+	b := newCountingBackend(10, 2)
+	RenderText("abc", 0, 0, 255, 0)
+	Flush()
+
+	b.Resize(12, 2)
+	b.cellsSet = 0
+	ClearScreen(0)
+	RenderText("abc", 0, 0, 255, 0)
+	Flush()
+	if b.cellsSet != 3 {
+		t.Errorf("Expected the text to be pushed again after resize, got %d cells", b.cellsSet)
+	}
+}
+
+func TestRepaintPushesEveryCell(t *testing.T) {
+	b := newCountingBackend(10, 2)
+	RenderText("abc", 0, 0, 255, 0)
+	Flush()
+
+	b.cellsSet = 0
+	Repaint()
+	Flush()
+	if b.cellsSet != 20 {
+		t.Errorf("Expected all 20 cells pushed, got %d", b.cellsSet)
+	}
+}
+
+// benchmarkScrolling ...
+// Scrolls the highlight through a list, redrawing the whole list each frame as the screens do.
+func benchmarkScrolling(b *testing.B, full bool) {
+	backend := newCountingBackend(120, 40)
+	for i := 0; i < b.N; i++ {
+		renderList(120, 40, 1+i%38)
+		buffer.flush(full)
+	}
+	b.ReportMetric(float64(backend.cellsSet)/float64(b.N), "cells/op")
+}
+
+func BenchmarkScrollingFullRedraw(b *testing.B) {
+	benchmarkScrolling(b, true)
+}
+
+func BenchmarkScrollingDiff(b *testing.B) {
+	benchmarkScrolling(b, false)
+}
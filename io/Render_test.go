@@ -0,0 +1,46 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "testing"
+
+func TestRenderTextCombiningMarks(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []rune
+	}{
+		{"cafe\u0301!", []rune{'c', 'a', 'f', '\u00e9', '!'}},
+		{"n\u0303o", []rune{'\u00f1', 'o'}},
+		{"\u0254\u0303!", []rune{'\u0254', '!'}}, // No precomposed form, so the tilde is dropped
+		{"\u0301a", []rune{'a'}},                 // A stray mark takes up no space
+	}
+	for _, test := range tests {
+		b := NewMemoryBackend(10, 1)
+		SetBackend(b)
+		RenderText(test.text, 0, 0, 255, 0)
+		Flush()
+		for x, expected := range test.expected {
+			if cell := b.GetCell(x, 0); cell.Ch != expected {
+				t.Errorf("%q: expected %q at column %d, got %q", test.text, expected, x, cell.Ch)
+			}
+		}
+		if cell := b.GetCell(len(test.expected), 0); cell.Ch != ' ' && cell.Ch != 0 {
+			t.Errorf("%q: expected nothing after column %d, got %q", test.text, len(test.expected)-1, cell.Ch)
+		}
+	}
+}
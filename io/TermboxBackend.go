@@ -0,0 +1,127 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "github.com/nsf/termbox-go"
+
+// TermboxBackend ...
+// Renders to the terminal using termbox.
+type TermboxBackend struct {
+	colorMode ColorMode
+}
+
+// termboxOutputModes ...
+// The termbox output mode used for each color mode.
+var termboxOutputModes = map[ColorMode]termbox.OutputMode{
+	ColorModeMonochrome: termbox.OutputNormal,
+	ColorMode8:          termbox.OutputNormal,
+	ColorMode16:         termbox.OutputNormal,
+	ColorMode256:        termbox.Output256,
+	ColorModeTrueColor:  termbox.OutputRGB,
+}
+
+// Init ...
+// Initializes termbox.
+func (b *TermboxBackend) Init() error {
+	err := termbox.Init()
+	if err != nil {
+		return err
+	}
+	b.SetColorMode(colorMode)
+
+	return nil
+}
+
+// SetColorMode ...
+// Sets the termbox output mode for a color mode.
+func (b *TermboxBackend) SetColorMode(mode ColorMode) {
+	b.colorMode = mode
+	termbox.SetOutputMode(termboxOutputModes[mode])
+}
+
+// Close ...
+// Closes termbox, restoring the terminal.
+func (b *TermboxBackend) Close() {
+	termbox.Close()
+}
+
+// SetCell ...
+// Sets the contents of a terminal cell.
+func (b *TermboxBackend) SetCell(x int, y int, ch rune, fgColor int, bgColor int) {
+	termbox.SetCell(x, y, ch, b.attribute(fgColor, defaultFgColor), b.attribute(bgColor, defaultBgColor))
+}
+
+// attribute ...
+// Converts a color to a termbox attribute (24-bit colors are encoded specially).
+// In truecolor mode termbox reads any attribute bits as part of the color (turning the default color black), so
+// the default color is replaced by the supplied stand-in (see SetDefaultColors) before attributes are combined with it.
+func (b *TermboxBackend) attribute(color int, defaultColor int) termbox.Attribute {
+	attrs := color &^ colorMask
+	color &= colorMask
+	if b.colorMode != ColorModeTrueColor {
+		return termbox.Attribute(color | attrs)
+	}
+	switch {
+	case color == 0 && attrs == 0:
+		return termbox.ColorDefault
+	case color == 0:
+		color = defaultColor
+	}
+
+	return termbox.RGBToAttribute(ColorToRGB(color)) | termbox.Attribute(attrs)
+}
+
+// Clear ...
+// Clears the terminal using the specified background color.
+func (b *TermboxBackend) Clear(bgColor int) {
+	termbox.Clear(termbox.ColorDefault, b.attribute(bgColor, defaultBgColor))
+}
+
+// Flush ...
+// Flushes changed cells to the terminal.
+func (b *TermboxBackend) Flush() {
+	termbox.Flush()
+}
+
+// PollEvent ...
+// Blocks until the next terminal event.
+func (b *TermboxBackend) PollEvent() Event {
+	event := termbox.PollEvent()
+	switch event.Type {
+	case termbox.EventKey:
+		return Event{Type: EventKey, Ch: event.Ch, Key: Key(event.Key)}
+	case termbox.EventResize:
+		return Event{Type: EventResize, Width: event.Width, Height: event.Height}
+	case termbox.EventInterrupt:
+		return Event{Type: EventInterrupt}
+	}
+
+	return Event{Type: EventNone}
+}
+
+// Interrupt ...
+// Wakes a blocked PollEvent, which returns an interrupt event.
+func (b *TermboxBackend) Interrupt() {
+	termbox.Interrupt()
+}
+
+// Size ...
+// Gets the current dimensions of the terminal.
+func (b *TermboxBackend) Size() (width int, height int) {
+	return termbox.Size()
+}
@@ -0,0 +1,50 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"testing"
+
+	"github.com/nsf/termbox-go"
+)
+
+func TestTermboxAttribute(t *testing.T) {
+	white := termbox.RGBToAttribute(ColorToRGB(255))
+	tests := []struct {
+		mode         ColorMode
+		color        int
+		defaultColor int
+		expected     termbox.Attribute
+	}{
+		{ColorMode256, 0, 255, termbox.ColorDefault},
+		{ColorMode256, 0 | AttrBold, 255, termbox.ColorDefault | termbox.AttrBold},
+		{ColorMode256, 197 | AttrUnderline, 255, termbox.Attribute(197) | termbox.AttrUnderline},
+		{ColorModeTrueColor, 0, 255, termbox.ColorDefault},
+		{ColorModeTrueColor, 0 | AttrBold, 255, white | termbox.AttrBold}, // The stand-in for the default color
+		{ColorModeTrueColor, 0 | AttrReverse, 255, white | termbox.AttrReverse},
+		{ColorModeTrueColor, 0 | AttrUnderline, 8, termbox.RGBToAttribute(229, 229, 229) | termbox.AttrUnderline},
+		{ColorModeTrueColor, 2, 255, termbox.RGBToAttribute(205, 0, 0)},
+		{ColorModeTrueColor, 2 | AttrBold, 255, termbox.RGBToAttribute(205, 0, 0) | termbox.AttrBold},
+	}
+	for _, test := range tests {
+		b := &TermboxBackend{colorMode: test.mode}
+		if attribute := b.attribute(test.color, test.defaultColor); attribute != test.expected {
+			t.Errorf("%s, color %#x: expected attribute %#x, got %#x", test.mode, test.color, test.expected, attribute)
+		}
+	}
+}
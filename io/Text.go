@@ -0,0 +1,237 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"strings"
+	"unicode"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ellipsis ...
+// The rune used to indicate that text has been truncated.
+const Ellipsis = '…'
+
+// Special runes that join or modify the rune before them
+const (
+	zeroWidthJoiner             = rune('\u200D')
+	variationSelectorEmoji      = rune('\uFE0F')
+	variationSelectorsStart     = rune('\uFE00')
+	variationSelectorsEnd       = rune('\uFE0F')
+	emojiModifiersStart         = rune('\U0001F3FB')
+	emojiModifiersEnd           = rune('\U0001F3FF')
+	regionalIndicatorsStart     = rune('\U0001F1E6')
+	regionalIndicatorsEnd       = rune('\U0001F1FF')
+	tagCharactersStart          = rune('\U000E0020')
+	tagCharactersEnd            = rune('\U000E007F')
+	emojiPresentationCellsWidth = 2
+)
+
+// Grapheme ...
+// Represents a user-perceived character: a base rune plus any runes that combine with it.
+type Grapheme struct {
+	Runes []rune // The runes in the cluster (the first is the base rune)
+	Width int    // The number of terminal columns the cluster occupies
+}
+
+// Graphemes ...
+// Splits a string into grapheme clusters.
+// Text is normalized first so that decomposed accents render as a single precomposed rune where possible.
+func Graphemes(text string) []Grapheme {
+	graphemes := []Grapheme{}
+	for _, r := range norm.NFC.String(text) {
+		last := len(graphemes) - 1
+		if last >= 0 && extendsGrapheme(graphemes[last].Runes, r) {
+			graphemes[last].Runes = append(graphemes[last].Runes, r)
+			graphemes[last].Width = graphemeWidth(graphemes[last].Runes)
+			continue
+		}
+		graphemes = append(graphemes, Grapheme{Runes: []rune{r}, Width: graphemeWidth([]rune{r})})
+	}
+
+	return graphemes
+}
+
+// extendsGrapheme ...
+// Determines whether a rune belongs to the grapheme cluster made up of the supplied runes.
+func extendsGrapheme(cluster []rune, r rune) bool {
+	previous := cluster[len(cluster)-1]
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r == zeroWidthJoiner || previous == zeroWidthJoiner:
+		return true
+	case r >= variationSelectorsStart && r <= variationSelectorsEnd:
+		return true
+	case r >= emojiModifiersStart && r <= emojiModifiersEnd:
+		return true
+	case r >= tagCharactersStart && r <= tagCharactersEnd:
+		return true
+	case isRegionalIndicator(r):
+		// Flags are pairs of regional indicators
+		return len(cluster) == 1 && isRegionalIndicator(previous)
+	}
+
+	return false
+}
+
+// graphemeWidth ...
+// Gets the number of terminal columns a grapheme cluster occupies.
+func graphemeWidth(cluster []rune) int {
+	width := runewidth.RuneWidth(cluster[0])
+	if width == 0 {
+		// Control characters and stray combining marks are not rendered
+		return 0
+	}
+
+	// Emoji sequences and flags render using emoji presentation
+	for _, r := range cluster[1:] {
+		if r == variationSelectorEmoji || r == zeroWidthJoiner || isRegionalIndicator(r) || (r >= emojiModifiersStart && r <= emojiModifiersEnd) {
+			return emojiPresentationCellsWidth
+		}
+	}
+
+	return width
+}
+
+// isRegionalIndicator ...
+// Determines whether a rune is a regional indicator symbol (used in pairs to form flags).
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorsStart && r <= regionalIndicatorsEnd
+}
+
+// TextWidth ...
+// Gets the number of terminal columns needed to render a string.
+// East Asian wide characters occupy two columns and combining marks occupy none. Arabic text is measured as it
+// is rendered (shaped, if shaping is configured), so a lam-alef ligature occupies one column.
+func TextWidth(text string) int {
+	if shapeArabicText {
+		text = ShapeArabic(text)
+	}
+	width := 0
+	for _, grapheme := range Graphemes(text) {
+		width += grapheme.Width
+	}
+
+	return width
+}
+
+// ClipText ...
+// Clips a string so that it fits within the specified number of columns.
+// Grapheme clusters are never split, so a wide character that would straddle the edge is dropped.
+func ClipText(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	// Walk the graphemes until the width is used up
+	var clipped strings.Builder
+	cols := 0
+	for _, grapheme := range Graphemes(text) {
+		if cols+grapheme.Width > width {
+			break
+		}
+		clipped.WriteString(string(grapheme.Runes))
+		cols += grapheme.Width
+	}
+
+	return clipped.String()
+}
+
+// TruncateText ...
+// Truncates a string so that it fits within the specified number of columns.
+// Truncated text ends with an ellipsis.
+func TruncateText(text string, width int) string {
+	if TextWidth(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	// Leave room for the ellipsis
+	return strings.TrimRightFunc(ClipText(text, width-1), unicode.IsSpace) + string(Ellipsis)
+}
+
+// WrapText ...
+// Word-wraps a string into lines that fit within the specified number of columns.
+// Newlines in the text start a new line. Words wider than a line are broken across lines. A character wider than
+// a line is put on a line of its own (which it overflows), so no text is lost.
+func WrapText(text string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	lines := []string{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines = append(lines, wrapParagraph(paragraph, width)...)
+	}
+
+	return lines
+}
+
+// wrapParagraph ...
+// Word-wraps a single paragraph (text without newlines).
+func wrapParagraph(paragraph string, width int) []string {
+	words := strings.Fields(paragraph)
+	if len(words) == 0 {
+		// Preserve blank lines
+		return []string{""}
+	}
+
+	lines := []string{}
+	line := ""
+	for _, word := range words {
+		// Break words that can never fit on a line
+		for TextWidth(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			word = norm.NFC.String(word)
+			head := ClipText(word, width)
+			if head == "" {
+				// Not even one character fits, so it goes on a line of its own
+				head = string(Graphemes(word)[0].Runes)
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		if word == "" {
+			continue
+		}
+
+		// Start a new line if the word does not fit on the current one
+		switch {
+		case line == "":
+			line = word
+		case TextWidth(line)+1+TextWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
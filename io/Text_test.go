@@ -0,0 +1,139 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClipText(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"hello", 3, "hel"},
+		{"hello", 10, "hello"},
+		{"hello", 1, "h"},
+		{"hello", 0, ""},
+		{"hello", -1, ""},
+		{"日本語", 3, "日"},
+		{"日本語", 1, ""},
+		{"e\u0301te", 2, "\u00e9t"},
+		{"👍🏽ok", 2, "👍🏽"},
+		{"🇫🇷x", 1, ""},
+	}
+	for _, test := range tests {
+		if clipped := ClipText(test.text, test.width); clipped != test.expected {
+			t.Errorf("%q clipped to %d: expected %q, got %q", test.text, test.width, test.expected, clipped)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"hello", 5, "hello"},
+		{"hello world", 8, "hello w…"},
+		{"hello world", 7, "hello…"},
+		{"hello", 1, "…"},
+		{"hello", 0, ""},
+		{"日本語", 4, "日…"},
+		{"日本語", 6, "日本語"},
+		{"👍👍", 3, "👍…"},
+	}
+	for _, test := range tests {
+		if truncated := TruncateText(test.text, test.width); truncated != test.expected {
+			t.Errorf("%q truncated to %d: expected %q, got %q", test.text, test.width, test.expected, truncated)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected []string
+	}{
+		{"the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"the quick brown fox", 40, []string{"the quick brown fox"}},
+		{"a\n\nb", 5, []string{"a", "", "b"}},
+		{"abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"hi there", 1, []string{"h", "i", "t", "h", "e", "r", "e"}},
+		{"日本語テキスト", 4, []string{"日本", "語テ", "キス", "ト"}},
+		{"日本語", 3, []string{"日", "本", "語"}},
+		{"日本", 1, []string{"日", "本"}}, // Wide characters overflow lines that are too narrow
+		{"a日本b c", 1, []string{"a", "日", "本", "b", "c"}},
+		{"日本 語", 1, []string{"日", "本", "語"}},
+		{"cafe\u0301 cre\u0300me", 5, []string{"cafe\u0301", "cre\u0300me"}},
+		{"hello", 0, nil},
+	}
+	for _, test := range tests {
+		if lines := WrapText(test.text, test.width); !reflect.DeepEqual(lines, test.expected) {
+			t.Errorf("%q wrapped to %d: expected %q, got %q", test.text, test.width, test.expected, lines)
+		}
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"日本", 4},
+		{"e\u0301", 1},
+		{"\u0254\u0303", 1},
+		{"a\x00b", 2},
+		{"👍", 2},
+		{"👍🏽", 2},
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467", 2},
+		{"🇯🇵", 2},
+		{"\u263A\uFE0F", 2},
+		{"\u0301", 0},
+	}
+	for _, test := range tests {
+		if width := TextWidth(test.text); width != test.expected {
+			t.Errorf("%q: expected width %d, got %d", test.text, test.expected, width)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []Grapheme
+	}{
+		{"ab", []Grapheme{{[]rune{'a'}, 1}, {[]rune{'b'}, 1}}},
+		{"e\u0301", []Grapheme{{[]rune{'\u00e9'}, 1}}},
+		{"\u0254\u0303", []Grapheme{{[]rune{'\u0254', '\u0303'}, 1}}},
+		{"語", []Grapheme{{[]rune{'語'}, 2}}},
+		{"🇫🇷🇯🇵", []Grapheme{{[]rune{'🇫', '🇷'}, 2}, {[]rune{'🇯', '🇵'}, 2}}},
+		{"👍🏽!", []Grapheme{{[]rune{'👍', '🏽'}, 2}, {[]rune{'!'}, 1}}},
+	}
+	for _, test := range tests {
+		if graphemes := Graphemes(test.text); !reflect.DeepEqual(graphemes, test.expected) {
+			t.Errorf("%q: expected graphemes %v, got %v", test.text, test.expected, graphemes)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package io
+
+import "time"
+
+// Timer ...
+// Represents a callback scheduled to run on the event loop, once or repeatedly.
+type Timer struct {
+	deadline  time.Time     // When the callback is next due
+	interval  time.Duration // Time between repeats (zero to run once)
+	callback  func()
+	cancelled bool
+}
+
+// Cancel ...
+// Stops the timer. The callback is not run again.
+func (t *Timer) Cancel() {
+	t.cancelled = true
+}
+
+// Schedule ...
+// Runs a callback on the event loop once the delay has passed. Call from the event loop.
+func (e *EventListener) Schedule(delay time.Duration, callback func()) *Timer {
+	timer := &Timer{deadline: e.now().Add(delay), callback: callback}
+	e.timers = append(e.timers, timer)
+
+	return timer
+}
+
+// Every ...
+// Runs a callback on the event loop each time the interval passes. Call from the event loop.
+func (e *EventListener) Every(interval time.Duration, callback func()) *Timer {
+	timer := &Timer{deadline: e.now().Add(interval), interval: interval, callback: callback}
+	e.timers = append(e.timers, timer)
+
+	return timer
+}
+
+// FireDueTimers ...
+// Runs the callbacks of timers that are due, rescheduling repeating timers. Returns the number of
+// callbacks run. Repeating timers that fell behind (e.g. while the computer slept) run once, not once
+// for each missed interval.
+func (e *EventListener) FireDueTimers() int {
+	now := e.now()
+	var due []*Timer
+	var remaining []*Timer
+	for _, timer := range e.timers {
+		if timer.cancelled {
+			continue
+		}
+		if timer.deadline.After(now) {
+			remaining = append(remaining, timer)
+			continue
+		}
+		due = append(due, timer)
+		if timer.interval > 0 {
+			timer.deadline = timer.deadline.Add(timer.interval)
+			if !timer.deadline.After(now) {
+				timer.deadline = now.Add(timer.interval)
+			}
+			remaining = append(remaining, timer)
+		}
+	}
+
+	// Callbacks may schedule or cancel timers, so run them after the list is rebuilt
+	e.timers = remaining
+	fired := 0
+	for _, timer := range due {
+		if !timer.cancelled {
+			fired++
+			timer.callback()
+		}
+	}
+
+	return fired
+}
+
+// nextDeadline ...
+// Gets the time at which the next timer is due.
+func (e *EventListener) nextDeadline() (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, timer := range e.timers {
+		if !timer.cancelled && (!found || timer.deadline.Before(next)) {
+			next = timer.deadline
+			found = true
+		}
+	}
+
+	return next, found
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screen
+
+import "github.com/stuartthompson/dailyvocab/io"
+
+// Alignment ...
+// Typedef for horizontal text alignment.
+type Alignment int
+
+// Defines alignment types.
+const (
+	AlignLeft = Alignment(iota)
+	AlignCenter
+	AlignRight
+)
+
+// NaturalAlignment ...
+// Gets the alignment that suits the direction of a string (right-to-left text is aligned to the right).
+func NaturalAlignment(text string) Alignment {
+	if io.TextDirection(text) == io.RightToLeft {
+		return AlignRight
+	}
+
+	return AlignLeft
+}
+
+// offset ...
+// Gets the column offset at which text of the given width should start within an area.
+func (a Alignment) offset(textWidth int, areaWidth int) int {
+	switch a {
+	case AlignCenter:
+		return (areaWidth - textWidth) / 2
+	case AlignRight:
+		return areaWidth - textWidth
+	default:
+		return 0
+	}
+}
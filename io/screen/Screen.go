@@ -18,18 +18,100 @@ func NewScreen(viewport *Viewport, style *Style) *Screen {
 // Clear ...
 // Clears the screen, ready for rendering.
 func (s *Screen) Clear() {
-	io.ClearArea(s.viewport.x, s.viewport.y, s.viewport.x+s.viewport.width, s.viewport.y+s.viewport.height, 0)
+	io.ClearArea(s.viewport.x, s.viewport.y, s.viewport.width, s.viewport.height, 0)
 	if s.style.ShowBorder == true {
-		io.RenderPaneBorder(s.viewport.x, s.viewport.y, s.viewport.width-1, s.viewport.height-1, s.style.BorderColor, 0)
+		io.RenderPaneBorder(s.viewport.x, s.viewport.y, s.viewport.width-1, s.viewport.height-1, s.style.Theme.Color(RoleBorder), 0)
 	}
 }
 
 // RenderText ...
 // Renders a string at relative coordinates within the canvas using the supplied colors.
+// Coordinates are relative to the content area (inside the border) and text is clipped to fit within it.
 func (s *Screen) RenderText(text string, x int, y int, fgColor int, bgColor int) {
-	// TODO: Check that text fits within the pane
-	// TODO: Clean up calculation of x and y position (too confusing)
-	io.RenderText(text, s.viewport.x+x+1, s.viewport.y+y+1, fgColor, bgColor)
+	contentX, contentY, contentWidth, contentHeight := s.contentArea()
+
+	// Skip text that falls outside the content area
+	if x < 0 || y < 0 || y >= contentHeight {
+		return
+	}
+
+	// Only clip text that does not fit (clipping measures Arabic before it is shaped, so it may clip more than needed)
+	if io.TextWidth(text) > contentWidth-x {
+		text = io.ClipText(text, contentWidth-x)
+	}
+	io.RenderText(text, contentX+x, contentY+y, fgColor, bgColor)
+}
+
+// RenderAlignedText ...
+// Renders a single line of text aligned within the width of the content area.
+// Text that is too wide to fit is truncated with an ellipsis.
+func (s *Screen) RenderAlignedText(text string, y int, alignment Alignment, fgColor int, bgColor int) {
+	s.RenderAlignedTextInColumn(text, 0, y, s.GetContentWidth(), alignment, fgColor, bgColor)
+}
+
+// RenderAlignedTextInColumn ...
+// Renders a single line of text aligned within a column of the content area.
+// Text that is too wide to fit is truncated with an ellipsis.
+func (s *Screen) RenderAlignedTextInColumn(text string, x int, y int, width int, alignment Alignment, fgColor int, bgColor int) {
+	text = io.TruncateText(text, width)
+	s.RenderText(text, x+alignment.offset(io.TextWidth(text), width), y, fgColor, bgColor)
+}
+
+// RenderParagraph ...
+// Word-wraps text into a column of the content area and renders it, one line per row starting at y.
+// Returns the number of lines the paragraph occupies so that following content can be placed below it.
+func (s *Screen) RenderParagraph(text string, x int, y int, width int, alignment Alignment, fgColor int, bgColor int) int {
+	lines := io.WrapText(text, width)
+	for i, line := range lines {
+		s.RenderAlignedTextInColumn(line, x, y+i, width, alignment, fgColor, bgColor)
+	}
+
+	return len(lines)
+}
+
+// RenderBox ...
+// Renders a border around an area of the content area. Boxes that do not fit within the content area are
+// not rendered. Returns whether the box was rendered.
+func (s *Screen) RenderBox(x int, y int, width int, height int, fgColor int, bgColor int) bool {
+	contentX, contentY, contentWidth, contentHeight := s.contentArea()
+	if x < 0 || y < 0 || width < 2 || height < 2 || x+width > contentWidth || y+height > contentHeight {
+		return false
+	}
+
+	io.RenderPaneBorder(contentX+x, contentY+y, width-1, height-1, fgColor, bgColor)
+
+	return true
+}
+
+// GetContentWidth ...
+// Gets the number of columns available for content (excluding the border).
+func (s *Screen) GetContentWidth() int {
+	_, _, width, _ := s.contentArea()
+	return width
+}
+
+// GetContentHeight ...
+// Gets the number of rows available for content (excluding the border).
+func (s *Screen) GetContentHeight() int {
+	_, _, _, height := s.contentArea()
+	return height
+}
+
+// contentArea ...
+// Gets the absolute position and size of the area inside the border.
+func (s *Screen) contentArea() (x int, y int, width int, height int) {
+	inset := 0
+	if s.style.ShowBorder {
+		inset = 1
+	}
+
+	return s.viewport.x + inset, s.viewport.y + inset, s.viewport.width - 2*inset, s.viewport.height - 2*inset
+}
+
+// GetTheme ...
+// Gets the theme this screen is rendered with.
+func (s *Screen) GetTheme() *Theme {
+	return s.style.Theme
 }
 
 // GetHeight ...
@@ -0,0 +1,56 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screen
+
+import (
+	"testing"
+
+	"github.com/stuartthompson/dailyvocab/io"
+)
+
+func TestRenderAlignedTextInColumnShapedArabic(t *testing.T) {
+	tests := []struct {
+		text      string
+		alignment Alignment
+		first     int // The first column the text fills
+		last      int // The last column the text fills
+	}{
+		{"مع السلامة", AlignRight, 11, 19}, // The lam-alef ligature takes one column
+		{"مع السلامة", AlignCenter, 5, 13},
+		{"سلام", AlignRight, 17, 19},
+		{"hello", AlignRight, 15, 19},
+	}
+	for _, test := range tests {
+		backend := io.NewMemoryBackend(20, 1)
+		io.SetBackend(backend)
+		s := NewScreen(NewViewport(0, 0, 20, 1), &Style{Theme: DarkTheme})
+		s.RenderAlignedTextInColumn(test.text, 0, 0, 20, test.alignment, 0, 0)
+		io.Flush()
+
+		filled := func(x int) bool {
+			if x < 0 || x >= 20 {
+				return false
+			}
+			ch := backend.GetCell(x, 0).Ch
+			return ch != ' ' && ch != 0
+		}
+		if !filled(test.first) || !filled(test.last) || filled(test.first-1) || filled(test.last+1) {
+			t.Errorf("%q: expected columns %d to %d to be filled, got %q", test.text, test.first, test.last, backend.String())
+		}
+	}
+}
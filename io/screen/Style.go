@@ -20,6 +20,6 @@ package screen
 // Style ...
 // Represents style settings for a canvas.
 type Style struct {
-	ShowBorder  bool
-	BorderColor int
+	ShowBorder bool
+	Theme      *Theme // The colors to use
 }
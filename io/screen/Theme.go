@@ -0,0 +1,187 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role ...
+// Typedef for the semantic roles that colors are chosen for.
+type Role string
+
+// Defines color roles.
+const (
+	RoleBorder    = Role("border")    // Screen borders
+	RoleTitle     = Role("title")     // Screen titles and headings
+	RoleText      = Role("text")      // Body text
+	RoleMuted     = Role("muted")     // Secondary text (e.g. romanizations)
+	RoleSuccess   = Role("success")   // Positive indicators (e.g. viewed checkmarks)
+	RoleError     = Role("error")     // Errors
+	RoleHighlight = Role("highlight") // Background of selected items
+)
+
+// Roles ...
+// All color roles, in display order.
+var Roles = []Role{RoleBorder, RoleTitle, RoleText, RoleMuted, RoleSuccess, RoleError, RoleHighlight}
+
+// Theme ...
+// Represents a named set of colors for each role, plus accent colors for each language.
+// Colors are 256-color codes as passed to the renderer (0 is the terminal's default color).
+type Theme struct {
+	Name    string
+	Colors  map[Role]int   // Colors by role
+	Accents map[string]int // Accent colors by language code
+}
+
+// Built-in themes
+var (
+	DarkTheme = &Theme{
+		Name: "dark",
+		Colors: map[Role]int{
+			RoleBorder:    5,   // Blue
+			RoleTitle:     255, // White
+			RoleText:      255, // White
+			RoleMuted:     246, // Grey
+			RoleSuccess:   3,   // Green
+			RoleError:     2,   // Red
+			RoleHighlight: 238, // Dark grey
+		},
+		Accents: map[string]int{
+			"en": 111, "fr": 69, "it": 78, "es": 209, "de": 221,
+			"ja": 205, "el": 75, "ar": 72, "he": 117, "fa": 179,
+		},
+	}
+	LightTheme = &Theme{
+		Name: "light",
+		Colors: map[Role]int{
+			RoleBorder:    19,  // Navy
+			RoleTitle:     18,  // Dark blue
+			RoleText:      1,   // Black
+			RoleMuted:     242, // Grey
+			RoleSuccess:   29,  // Dark green
+			RoleError:     125, // Dark red
+			RoleHighlight: 252, // Light grey
+		},
+		Accents: map[string]int{
+			"en": 25, "fr": 19, "it": 29, "es": 131, "de": 137,
+			"ja": 126, "el": 26, "ar": 23, "he": 31, "fa": 95,
+		},
+	}
+	HighContrastTheme = &Theme{
+		Name: "high-contrast",
+		Colors: map[Role]int{
+			RoleBorder:    16, // Bright white
+			RoleTitle:     12, // Bright yellow
+			RoleText:      16, // Bright white
+			RoleMuted:     16, // Bright white
+			RoleSuccess:   11, // Bright green
+			RoleError:     10, // Bright red
+			RoleHighlight: 5,  // Blue
+		},
+		Accents: map[string]int{},
+	}
+)
+
+// BuiltInThemes ...
+// The built-in themes, in display order.
+var BuiltInThemes = []*Theme{DarkTheme, LightTheme, HighContrastTheme}
+
+// GetBuiltInTheme ...
+// Gets a built-in theme by name (or nil if there is no theme with that name).
+func GetBuiltInTheme(name string) *Theme {
+	for _, theme := range BuiltInThemes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+
+	return nil
+}
+
+// Copy ...
+// Creates a copy of the theme that can be modified without changing this one.
+func (t *Theme) Copy() *Theme {
+	theme, _ := t.Derive(t.Name, nil, nil)
+	return theme
+}
+
+// Color ...
+// Gets the color for a role. Roles missing from the theme use the dark theme's color.
+func (t *Theme) Color(role Role) int {
+	if color, ok := t.Colors[role]; ok {
+		return color
+	}
+
+	return DarkTheme.Colors[role]
+}
+
+// Accent ...
+// Gets the accent color for a language. Regional codes (e.g. "en-us") fall back to the language ("en"),
+// and languages without an accent use the text color.
+func (t *Theme) Accent(languageCode string) int {
+	languageCode = strings.ToLower(languageCode)
+	if color, ok := t.Accents[languageCode]; ok {
+		return color
+	}
+	if ix := strings.Index(languageCode, "-"); ix > 0 {
+		if color, ok := t.Accents[languageCode[:ix]]; ok {
+			return color
+		}
+	}
+
+	return t.Color(RoleText)
+}
+
+// Derive ...
+// Creates a new theme based on this one, with some colors replaced.
+// Colors are keyed by role name; an error is returned for names that are not roles.
+func (t *Theme) Derive(name string, colors map[string]int, accents map[string]int) (*Theme, error) {
+	derived := &Theme{Name: name, Colors: map[Role]int{}, Accents: map[string]int{}}
+	for role, color := range t.Colors {
+		derived.Colors[role] = color
+	}
+	for languageCode, color := range t.Accents {
+		derived.Accents[languageCode] = color
+	}
+
+	for roleName, color := range colors {
+		if !isRole(Role(roleName)) {
+			return nil, fmt.Errorf("unknown color role %q in theme %q", roleName, name)
+		}
+		derived.Colors[Role(roleName)] = color
+	}
+	for languageCode, color := range accents {
+		derived.Accents[strings.ToLower(languageCode)] = color
+	}
+
+	return derived, nil
+}
+
+// isRole ...
+// Determines whether a role is one of the defined roles.
+func isRole(role Role) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screen
+
+import "testing"
+
+func TestThemeAccentFallsBackToLanguage(t *testing.T) {
+	theme := &Theme{Name: "test", Colors: map[Role]int{RoleText: 7}, Accents: map[string]int{"en": 20}}
+
+	if color := theme.Accent("EN-us"); color != 20 {
+		t.Errorf("Expected regional code to use the language accent, got %d", color)
+	}
+	if color := theme.Accent("ja"); color != 7 {
+		t.Errorf("Expected language without an accent to use the text color, got %d", color)
+	}
+	if color := theme.Color(RoleBorder); color != DarkTheme.Color(RoleBorder) {
+		t.Errorf("Expected missing role to use the dark theme's color, got %d", color)
+	}
+}
+
+func TestThemeDerive(t *testing.T) {
+	derived, err := LightTheme.Derive("mine", map[string]int{"border": 99}, map[string]int{"FR": 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if derived.Name != "mine" || derived.Color(RoleBorder) != 99 || derived.Accent("fr") != 42 {
+		t.Errorf("Overrides not applied: %+v", derived)
+	}
+	if derived.Color(RoleText) != LightTheme.Color(RoleText) {
+		t.Error("Expected colors that are not overridden to come from the base theme")
+	}
+	if LightTheme.Color(RoleBorder) == 99 {
+		t.Error("Deriving a theme must not modify the base theme")
+	}
+
+	if _, err := DarkTheme.Derive("bad", map[string]int{"sparkle": 1}, nil); err == nil {
+		t.Error("Expected an error for an unknown role")
+	}
+}
@@ -31,3 +31,12 @@ type Viewport struct {
 func NewViewport(x int, y int, width int, height int) *Viewport {
 	return &Viewport{x: x, y: y, width: width, height: height}
 }
+
+// MoveAndResize ...
+// Moves the viewport and resizes it. Screens sharing the viewport are laid out again when next rendered.
+func (v *Viewport) MoveAndResize(x int, y int, width int, height int) {
+	v.x = x
+	v.y = y
+	v.width = width
+	v.height = height
+}
@@ -17,6 +17,35 @@
 
 package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
 func main() {
-	NewApp().Run()
+	profile := flag.String("profile", "", "name of the profile to study as (default \"default\")")
+	flag.Parse()
+
+	app := NewApp()
+	if *profile != "" {
+		if err := configuration.ValidateProfileName(*profile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		app.configuration.Profile = *profile
+	}
+
+	// Run a command if one is given (e.g. "dailyvocab stats --format json")
+	if flag.NArg() > 0 {
+		if err := app.RunCommand(flag.Args(), os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	app.Run()
 }
@@ -0,0 +1,63 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package progress
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
+// Standing ...
+// Represents a profile's place on the leaderboard.
+type Standing struct {
+	Profile       string `json:"profile"`
+	WordsLearned  int    `json:"words-learned"`
+	Reviews       int    `json:"reviews"`
+	CurrentStreak int    `json:"current-streak"`
+	LongestStreak int    `json:"longest-streak"`
+}
+
+// Leaderboard ...
+// Ranks profiles by words learned, then by current streak (ties are ordered by name).
+func Leaderboard(profiles []*configuration.AppConfig, now time.Time) []Standing {
+	var standings []Standing
+	for _, profile := range profiles {
+		summary := Compute(profile, now)
+		standings = append(standings, Standing{
+			Profile:       profile.ProfileName(),
+			WordsLearned:  len(profile.ViewedWords),
+			Reviews:       len(profile.Reviews),
+			CurrentStreak: summary.CurrentStreak,
+			LongestStreak: summary.LongestStreak,
+		})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.WordsLearned != b.WordsLearned {
+			return a.WordsLearned > b.WordsLearned
+		}
+		if a.CurrentStreak != b.CurrentStreak {
+			return a.CurrentStreak > b.CurrentStreak
+		}
+		return a.Profile < b.Profile
+	})
+
+	return standings
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
+func TestLeaderboard(t *testing.T) {
+	now := time.Date(2018, time.June, 3, 12, 0, 0, 0, time.UTC)
+	alice := testConfiguration(configuration.GoalConfig{}, false, []string{"2018-06-02T09:00:00Z", "2018-06-03T09:00:00Z"}, nil)
+	alice.Profile = "alice"
+	bob := testConfiguration(configuration.GoalConfig{}, false, []string{"2018-06-01T09:00:00Z", "2018-06-03T09:00:00Z"}, nil)
+	bob.Profile = "bob"
+	carol := testConfiguration(configuration.GoalConfig{}, false, []string{"2018-05-01T09:00:00Z", "2018-05-01T10:00:00Z", "2018-05-01T11:00:00Z"}, nil)
+
+	standings := Leaderboard([]*configuration.AppConfig{bob, carol, alice}, now)
+	var names []string
+	for _, standing := range standings {
+		names = append(names, standing.Profile)
+	}
+	if len(names) != 3 || names[0] != "default" || names[1] != "alice" || names[2] != "bob" {
+		t.Errorf("Expected most words learned, then longest current streak first, got %v", names)
+	}
+	if standings[1].WordsLearned != 2 || standings[1].CurrentStreak != 2 || standings[2].CurrentStreak != 1 {
+		t.Errorf("Unexpected standings %+v", standings)
+	}
+}
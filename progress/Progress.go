@@ -0,0 +1,162 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package progress
+
+import (
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
+// maxFreezes ...
+// The most streak freezes that can be saved up.
+const maxFreezes = 2
+
+// Activity ...
+// Represents the study done on one study day.
+type Activity struct {
+	NewWords int // Words marked viewed
+	Reviews  int // Words reviewed
+}
+
+// Summary ...
+// Represents progress: streaks, today's study and the daily goal.
+type Summary struct {
+	CurrentStreak int                      // Consecutive days (ending today or yesterday) on which the goal was met
+	LongestStreak int                      // Most consecutive days on which the goal was met
+	Today         Activity                 // Study done today
+	Goal          configuration.GoalConfig // The daily goal
+	Freezes       int                      // Streak freezes saved up (when freezes are enabled)
+	FrozenDays    []time.Time              // Missed days on which a freeze kept the streak going
+}
+
+// GoalMet ...
+// Determines whether today's goal has been met.
+func (s Summary) GoalMet() bool {
+	return s.Today.MeetsGoal(s.Goal)
+}
+
+// History ...
+// Gets the study done on each study day (keyed by the dates returned by AppConfig.StudyDay), from the
+// journal's events. Words whose progress was later reset still count on the days they were studied.
+// Events with timestamps that cannot be read are ignored.
+func History(config *configuration.AppConfig) map[time.Time]Activity {
+	history := make(map[time.Time]Activity)
+	learned := make(map[int]bool)
+	for _, event := range config.Events() {
+		at, err := time.Parse(time.RFC3339, event.At)
+		if err != nil {
+			continue
+		}
+		day := config.StudyDay(at)
+		activity := history[day]
+		switch event.Type {
+		case configuration.EventLearned:
+			if learned[event.ID] {
+				continue
+			}
+			learned[event.ID] = true
+			activity.NewWords++
+		case configuration.EventReviewed, configuration.EventGraded:
+			if !learned[event.ID] {
+				continue
+			}
+			activity.Reviews++
+		case configuration.EventReset:
+			delete(learned, event.ID)
+			continue
+		default:
+			continue
+		}
+		history[day] = activity
+	}
+
+	return history
+}
+
+// Compute ...
+// Computes progress from the study history as of a time.
+// Days count towards a streak when the daily goal is met. A streak is kept until the end of the day after
+// it was last extended, so it is not lost before today's goal has been met. When freezes are enabled, a day
+// that exceeds the goal earns a freeze, and a freeze is used up to keep the streak going over a missed day.
+func Compute(config *configuration.AppConfig, now time.Time) Summary {
+	history := History(config)
+	today := config.StudyDay(now)
+	summary := Summary{Today: history[today], Goal: config.DailyGoal}
+
+	// Walk forward from the first day studied
+	first := today
+	for day := range history {
+		if day.Before(first) {
+			first = day
+		}
+	}
+	streak := 0
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		activity := history[day]
+		switch {
+		case activity.MeetsGoal(config.DailyGoal):
+			streak++
+			if config.StreakFreezes && activity.ExceedsGoal(config.DailyGoal) && summary.Freezes < maxFreezes {
+				summary.Freezes++
+			}
+		case day.Equal(today):
+			// Today is not over yet
+		case config.StreakFreezes && streak > 0 && summary.Freezes > 0:
+			summary.Freezes--
+			summary.FrozenDays = append(summary.FrozenDays, day)
+		default:
+			streak = 0
+		}
+		if streak > summary.LongestStreak {
+			summary.LongestStreak = streak
+		}
+	}
+	summary.CurrentStreak = streak
+
+	return summary
+}
+
+// MeetsGoal ...
+// Determines whether the study meets a goal (any study meets an empty goal).
+func (a Activity) MeetsGoal(goal configuration.GoalConfig) bool {
+	return a.NewWords+a.Reviews > 0 && a.NewWords >= goal.NewWords && a.Reviews >= goal.Reviews
+}
+
+// ExceedsGoal ...
+// Determines whether the study goes beyond a (non-empty) goal.
+func (a Activity) ExceedsGoal(goal configuration.GoalConfig) bool {
+	done, total := a.GoalProgress(goal)
+	return total > 0 && done == total && a.NewWords+a.Reviews > total
+}
+
+// GoalProgress ...
+// Gets how much of a goal the study has done (new words and reviews beyond the goal do not count).
+func (a Activity) GoalProgress(goal configuration.GoalConfig) (done int, total int) {
+	return minInt(a.NewWords, goal.NewWords) + minInt(a.Reviews, goal.Reviews), goal.NewWords + goal.Reviews
+}
+
+// minInt ...
+// Gets the smaller of two integers.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
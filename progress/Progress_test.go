@@ -0,0 +1,132 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
+// testConfiguration ...
+// Creates configuration with words learned and reviewed at the given times.
+func testConfiguration(goal configuration.GoalConfig, freezes bool, viewedAt []string, reviewedAt []string) *configuration.AppConfig {
+	config := &configuration.AppConfig{TimeZone: "UTC", DailyGoal: goal, StreakFreezes: freezes}
+	for i, at := range viewedAt {
+		config.MarkWordViewed(i+1, parseTime(at))
+	}
+	for _, at := range reviewedAt {
+		config.RecordReview(1, parseTime(at))
+	}
+
+	return config
+}
+
+// parseTime ...
+// Parses an RFC 3339 timestamp (keeping its offset from UTC).
+func parseTime(value string) time.Time {
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return at
+}
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2018, time.June, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		viewedAt []string
+		current  int
+		longest  int
+	}{
+		{"none", nil, 0, 0},
+		{"today", []string{"2018-06-10T08:00:00Z"}, 1, 1},
+		{"yesterday keeps the streak", []string{"2018-06-09T23:00:00Z"}, 1, 1},
+		{"consecutive days", []string{"2018-06-08T10:00:00Z", "2018-06-09T10:00:00Z", "2018-06-10T07:00:00Z", "2018-06-10T08:00:00Z"}, 3, 3},
+		{"gap ends the streak", []string{"2018-06-03T10:00:00Z", "2018-06-04T10:00:00Z", "2018-06-05T10:00:00Z", "2018-06-09T10:00:00Z", "2018-06-10T07:00:00Z"}, 2, 3},
+		{"two days ago", []string{"2018-06-08T10:00:00Z"}, 0, 1},
+		{"days are counted in the time zone", []string{"2018-06-08T23:30:00-02:00"}, 1, 1},
+	}
+
+	for _, test := range tests {
+		summary := Compute(testConfiguration(configuration.GoalConfig{}, false, test.viewedAt, nil), now)
+		if summary.CurrentStreak != test.current || summary.LongestStreak != test.longest {
+			t.Errorf("%s: expected streaks %d (longest %d), got %d (longest %d)", test.name, test.current, test.longest, summary.CurrentStreak, summary.LongestStreak)
+		}
+	}
+}
+
+func TestGoal(t *testing.T) {
+	now := time.Date(2018, time.June, 10, 9, 0, 0, 0, time.UTC)
+	goal := configuration.GoalConfig{NewWords: 1, Reviews: 2}
+
+	// Yesterday met the goal; today has one review left to do
+	config := testConfiguration(goal, false,
+		[]string{"2018-06-09T08:00:00Z", "2018-06-10T08:00:00Z"},
+		[]string{"2018-06-09T08:10:00Z", "2018-06-09T08:20:00Z", "2018-06-10T08:10:00Z"})
+	summary := Compute(config, now)
+	if summary.CurrentStreak != 1 || summary.GoalMet() {
+		t.Errorf("Expected a streak of 1 without today's goal met, got %+v", summary)
+	}
+	if done, total := summary.Today.GoalProgress(goal); done != 2 || total != 3 {
+		t.Errorf("Expected progress 2/3, got %d/%d", done, total)
+	}
+
+	config.RecordReview(1, parseTime("2018-06-10T08:30:00Z"))
+	summary = Compute(config, now)
+	if summary.CurrentStreak != 2 || !summary.GoalMet() {
+		t.Errorf("Expected a streak of 2 with today's goal met, got %+v", summary)
+	}
+
+	// Days that fall short of the goal break the streak
+	config = testConfiguration(goal, false, []string{"2018-06-08T08:00:00Z", "2018-06-09T08:00:00Z"}, []string{"2018-06-08T08:10:00Z", "2018-06-08T08:20:00Z"})
+	if summary := Compute(config, now); summary.CurrentStreak != 0 || summary.LongestStreak != 1 {
+		t.Errorf("Expected the streak to be broken, got %+v", summary)
+	}
+}
+
+func TestStreakFreezes(t *testing.T) {
+	now := time.Date(2018, time.June, 10, 9, 0, 0, 0, time.UTC)
+	goal := configuration.GoalConfig{NewWords: 1}
+
+	// Two words on the 7th exceeds the goal and earns a freeze, which covers the 8th
+	viewedAt := []string{"2018-06-07T08:00:00Z", "2018-06-07T08:10:00Z", "2018-06-09T08:00:00Z"}
+	summary := Compute(testConfiguration(goal, true, viewedAt, nil), now)
+	if summary.CurrentStreak != 2 || summary.Freezes != 0 || len(summary.FrozenDays) != 1 || summary.FrozenDays[0].Day() != 8 {
+		t.Errorf("Expected a freeze to keep the streak over the 8th, got %+v", summary)
+	}
+
+	// Without freezes the streak is broken
+	summary = Compute(testConfiguration(goal, false, viewedAt, nil), now)
+	if summary.CurrentStreak != 1 || summary.Freezes != 0 {
+		t.Errorf("Expected the streak to be broken without freezes, got %+v", summary)
+	}
+
+	// Freezes are limited
+	viewedAt = nil
+	for day := 1; day <= 5; day++ {
+		at := time.Date(2018, time.June, day, 8, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		viewedAt = append(viewedAt, at, at)
+	}
+	if summary := Compute(testConfiguration(goal, true, viewedAt, nil), now); summary.Freezes != 0 || summary.CurrentStreak != 0 || len(summary.FrozenDays) != maxFreezes {
+		t.Errorf("Expected %d freezes to be used before the streak was broken, got %+v", maxFreezes, summary)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package progress
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
+// Stats ...
+// Represents statistics about the study history.
+type Stats struct {
+	WordsLearned          int                `json:"words-learned"`
+	Reviews               int                `json:"reviews"`
+	QuizAccuracy          *float64           `json:"quiz-accuracy"`                // Fraction of graded reviews answered correctly (nil if none were graded)
+	AverageReviewInterval float64            `json:"average-review-interval-days"` // Average days between learning or reviewing a word and its next review
+	CurrentStreak         int                `json:"current-streak"`
+	LongestStreak         int                `json:"longest-streak"`
+	LearnedByMonth        []MonthCount       `json:"learned-by-month"` // Words learned in each month, oldest first
+	Languages             []LanguageCoverage `json:"languages"`        // Coverage of each language, by language code
+	StudyDays             []DayCount         `json:"study-days"`       // Study done on each day that had any, oldest first
+}
+
+// MonthCount ...
+// Represents the number of words learned in a month.
+type MonthCount struct {
+	Month string `json:"month"` // Year and month (e.g. "2018-06")
+	Words int    `json:"words"`
+}
+
+// LanguageCoverage ...
+// Represents how many of the words with a translation in a language have been studied.
+type LanguageCoverage struct {
+	Language string `json:"language"`
+	Studied  int    `json:"studied"` // Words learned that have a translation in the language
+	Total    int    `json:"total"`   // Words that have a translation in the language
+}
+
+// DayCount ...
+// Represents the study done on a day.
+type DayCount struct {
+	Date     string `json:"date"` // Study day (e.g. "2018-06-01")
+	NewWords int    `json:"new-words"`
+	Reviews  int    `json:"reviews"`
+}
+
+// ComputeStats ...
+// Computes statistics from the study history and vocabulary as of a time.
+func ComputeStats(config *configuration.AppConfig, vocabulary *app.Vocabulary, now time.Time) Stats {
+	summary := Compute(config, now)
+	stats := Stats{
+		WordsLearned:   len(config.ViewedWords),
+		Reviews:        len(config.Reviews),
+		CurrentStreak:  summary.CurrentStreak,
+		LongestStreak:  summary.LongestStreak,
+		LearnedByMonth: []MonthCount{},
+		Languages:      languageCoverage(config, vocabulary),
+		StudyDays:      []DayCount{},
+	}
+
+	// Quiz accuracy (of graded reviews, including those of words that were later reset)
+	graded, correct := 0, 0
+	for _, event := range config.Events() {
+		if event.Type == configuration.EventGraded && event.Score != nil {
+			graded++
+			if event.Recalled() {
+				correct++
+			}
+		}
+	}
+	if graded > 0 {
+		accuracy := float64(correct) / float64(graded)
+		stats.QuizAccuracy = &accuracy
+	}
+	stats.AverageReviewInterval = averageReviewInterval(config)
+
+	// Study days and words learned by month
+	history := History(config)
+	days := make([]time.Time, 0, len(history))
+	for day := range history {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	for _, day := range days {
+		activity := history[day]
+		stats.StudyDays = append(stats.StudyDays, DayCount{Date: day.Format("2006-01-02"), NewWords: activity.NewWords, Reviews: activity.Reviews})
+		if activity.NewWords == 0 {
+			continue
+		}
+		month := day.Format("2006-01")
+		if n := len(stats.LearnedByMonth); n > 0 && stats.LearnedByMonth[n-1].Month == month {
+			stats.LearnedByMonth[n-1].Words += activity.NewWords
+		} else {
+			stats.LearnedByMonth = append(stats.LearnedByMonth, MonthCount{Month: month, Words: activity.NewWords})
+		}
+	}
+
+	return stats
+}
+
+// languageCoverage ...
+// Computes the coverage of each language being learned, sorted by language code.
+func languageCoverage(config *configuration.AppConfig, vocabulary *app.Vocabulary) []LanguageCoverage {
+	pair := config.StudyPair()
+	learned := make(map[int]bool)
+	for _, viewed := range config.ViewedWords {
+		learned[viewed.ID] = true
+	}
+
+	coverage := make(map[string]*LanguageCoverage)
+	for _, word := range vocabulary.Words {
+		for _, translation := range word.Translations {
+			if !pair.IsLearning(translation.LanguageCode) {
+				continue
+			}
+			language, ok := coverage[translation.LanguageCode]
+			if !ok {
+				language = &LanguageCoverage{Language: translation.LanguageCode}
+				coverage[translation.LanguageCode] = language
+			}
+			language.Total++
+			if learned[word.ID] {
+				language.Studied++
+			}
+		}
+	}
+
+	languages := make([]LanguageCoverage, 0, len(coverage))
+	for _, language := range coverage {
+		languages = append(languages, *language)
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i].Language < languages[j].Language })
+
+	return languages
+}
+
+// averageReviewInterval ...
+// Computes the average number of days between learning or reviewing a word and its next review.
+func averageReviewInterval(config *configuration.AppConfig) float64 {
+	// Most recent study time of each word
+	last := make(map[int]time.Time)
+	for _, viewed := range config.ViewedWords {
+		if at, err := time.Parse(time.RFC3339, viewed.MarkedViewedAt); err == nil {
+			last[viewed.ID] = at
+		}
+	}
+
+	// Reviews are stored in the order they happened
+	total := 0.0
+	intervals := 0
+	for _, review := range config.Reviews {
+		at, err := time.Parse(time.RFC3339, review.ReviewedAt)
+		if err != nil {
+			continue
+		}
+		if previous, ok := last[review.ID]; ok {
+			total += at.Sub(previous).Hours() / 24
+			intervals++
+		}
+		last[review.ID] = at
+	}
+	if intervals == 0 {
+		return 0
+	}
+
+	return total / float64(intervals)
+}
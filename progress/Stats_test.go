@@ -0,0 +1,107 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+)
+
+func TestComputeStats(t *testing.T) {
+	config := &configuration.AppConfig{DefaultLanguage: "en-us", TimeZone: "UTC"}
+	config.MarkWordViewed(1, time.Date(2018, time.May, 30, 8, 0, 0, 0, time.UTC))
+	config.RecordGrade(1, time.Date(2018, time.May, 31, 8, 0, 0, 0, time.UTC), 1)
+	config.MarkWordViewed(2, time.Date(2018, time.June, 1, 8, 0, 0, 0, time.UTC))
+	config.RecordGrade(1, time.Date(2018, time.June, 2, 8, 0, 0, 0, time.UTC), 0)
+	config.RecordReview(2, time.Date(2018, time.June, 2, 8, 0, 0, 0, time.UTC))
+	vocabulary := &app.Vocabulary{Words: []app.Word{
+		{ID: 1, Translations: []app.LocalizedWord{{LanguageCode: "en-us"}, {LanguageCode: "fr"}}},
+		{ID: 2, Translations: []app.LocalizedWord{{LanguageCode: "en-us"}}},
+		{ID: 3, Translations: []app.LocalizedWord{{LanguageCode: "en-us"}, {LanguageCode: "fr"}}},
+	}}
+
+	stats := ComputeStats(config, vocabulary, time.Date(2018, time.June, 2, 9, 0, 0, 0, time.UTC))
+
+	if stats.WordsLearned != 2 || stats.Reviews != 3 {
+		t.Errorf("Expected 2 words learned and 3 reviews, got %d and %d", stats.WordsLearned, stats.Reviews)
+	}
+	if stats.QuizAccuracy == nil || *stats.QuizAccuracy != 0.5 {
+		t.Errorf("Expected quiz accuracy 0.5, got %v", stats.QuizAccuracy)
+	}
+	if stats.AverageReviewInterval != 4.0/3 {
+		t.Errorf("Expected average review interval of 4/3 days, got %v", stats.AverageReviewInterval)
+	}
+	if stats.CurrentStreak != 4 || stats.LongestStreak != 4 {
+		t.Errorf("Expected streaks of 4, got %d and %d", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if len(stats.LearnedByMonth) != 2 || stats.LearnedByMonth[0] != (MonthCount{"2018-05", 1}) || stats.LearnedByMonth[1] != (MonthCount{"2018-06", 1}) {
+		t.Errorf("Unexpected words learned by month %+v", stats.LearnedByMonth)
+	}
+	expectedLanguages := []LanguageCoverage{{"fr", 1, 2}}
+	if len(stats.Languages) != len(expectedLanguages) || stats.Languages[0] != expectedLanguages[0] {
+		t.Errorf("Expected language coverage %+v, got %+v", expectedLanguages, stats.Languages)
+	}
+	if len(stats.StudyDays) != 4 || stats.StudyDays[3] != (DayCount{"2018-06-02", 0, 2}) {
+		t.Errorf("Unexpected study days %+v", stats.StudyDays)
+	}
+}
+
+func TestComputeStatsWithoutHistory(t *testing.T) {
+	stats := ComputeStats(&configuration.AppConfig{TimeZone: "UTC"}, &app.Vocabulary{}, time.Date(2018, time.June, 2, 9, 0, 0, 0, time.UTC))
+	if stats.QuizAccuracy != nil || stats.AverageReviewInterval != 0 || stats.LearnedByMonth == nil || stats.StudyDays == nil {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestComputeStatsAfterReset(t *testing.T) {
+	// Three words learned on three days
+	config := &configuration.AppConfig{DefaultLanguage: "en-us", TimeZone: "UTC"}
+	for day := 1; day <= 3; day++ {
+		config.MarkWordViewed(day, time.Date(2018, time.June, day, 8, 0, 0, 0, time.UTC))
+	}
+	config.RecordGrade(1, time.Date(2018, time.June, 3, 8, 10, 0, 0, time.UTC), 1)
+
+	// Resetting a word no longer learned keeps the days it was studied
+	config.ResetWord(2, time.Date(2018, time.June, 3, 9, 0, 0, 0, time.UTC))
+	config.ResetWord(1, time.Date(2018, time.June, 3, 9, 0, 0, 0, time.UTC))
+	stats := ComputeStats(config, &app.Vocabulary{}, time.Date(2018, time.June, 3, 10, 0, 0, 0, time.UTC))
+	if stats.WordsLearned != 1 {
+		t.Errorf("Expected 1 word learned after the reset, got %d", stats.WordsLearned)
+	}
+	if stats.CurrentStreak != 3 || stats.LongestStreak != 3 {
+		t.Errorf("Expected streaks of 3 after the reset, got %d and %d", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if len(stats.StudyDays) != 3 || stats.StudyDays[1] != (DayCount{"2018-06-02", 1, 0}) || stats.StudyDays[2] != (DayCount{"2018-06-03", 1, 1}) {
+		t.Errorf("Unexpected study days after the reset %+v", stats.StudyDays)
+	}
+	if len(stats.LearnedByMonth) != 1 || stats.LearnedByMonth[0] != (MonthCount{"2018-06", 3}) {
+		t.Errorf("Unexpected words learned by month after the reset %+v", stats.LearnedByMonth)
+	}
+	if stats.QuizAccuracy == nil || *stats.QuizAccuracy != 1 {
+		t.Errorf("Expected quiz accuracy 1 after the reset, got %v", stats.QuizAccuracy)
+	}
+
+	// Relearning a reset word counts again
+	config.MarkWordViewed(2, time.Date(2018, time.June, 3, 9, 30, 0, 0, time.UTC))
+	if activity := History(config)[time.Date(2018, time.June, 3, 0, 0, 0, 0, time.UTC)]; activity.NewWords != 2 {
+		t.Errorf("Expected the relearned word to count, got %+v", activity)
+	}
+}
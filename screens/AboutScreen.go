@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
 	"github.com/stuartthompson/dailyvocab/io/screen"
 )
 
@@ -32,8 +33,8 @@ type AboutScreen struct {
 
 // NewAboutScreen ...
 // Instantiates a new about screen.
-func NewAboutScreen(config *configuration.AppConfig, viewport *screen.Viewport) *AboutScreen {
-	screenStyle := &screen.Style{ShowBorder: true, BorderColor: 218}
+func NewAboutScreen(config *configuration.AppConfig, viewport *screen.Viewport, theme *screen.Theme) *AboutScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
 	screen := screen.NewScreen(viewport, screenStyle)
 	return &AboutScreen{screen: screen, configuration: config}
 }
@@ -42,36 +43,41 @@ func NewAboutScreen(config *configuration.AppConfig, viewport *screen.Viewport)
 // Renders the about screen.
 func (s *AboutScreen) Render() {
 	s.screen.Clear()
+	theme := s.screen.GetTheme()
 
-	s.screen.RenderText("About", 1, 1, 255, 0)
-	s.screen.RenderText("DailyVocab presents a word of the day in different languages.", 1, 3, 255, 0)
+	s.screen.RenderText("About", 1, 1, theme.Color(screen.RoleTitle), 0)
+	descriptionLines := s.screen.RenderParagraph("DailyVocab presents a word of the day in different languages.", 1, 3, s.screen.GetContentWidth()-2, screen.AlignLeft, theme.Color(screen.RoleText), 0)
 
-	// Render color grid
-	s.screen.RenderText("Colors", 1, 5, 255, 0)
+	// Render color grid (showing the colors available in the active color mode)
+	colorMode := io.GetColorMode()
+	s.screen.RenderText(fmt.Sprintf("Colors (%s)", colorModeDescription(colorMode)), 1, 4+descriptionLines, theme.Color(screen.RoleTitle), 0)
 
-	rowIndex := 0
-	columnIndex := 0
 	xOffset := 1
-	yOffset := 6
-	for colorCode := 0; colorCode < 256; colorCode++ {
-		str := fmt.Sprintf("%d", colorCode)
-		s.screen.RenderText(str, columnIndex+xOffset, rowIndex+yOffset, colorCode, 0)
-		rowIndex++
-		if rowIndex+yOffset > s.screen.GetHeight()-4 {
-			columnIndex += 4
-			rowIndex = 0
-		}
+	yOffset := 5 + descriptionLines
+	numColors := colorMode.NumColors()
+	if numColors == 0 {
+		// Monochrome terminals only have attributes
+		s.screen.RenderText("bold", xOffset, yOffset, io.AttrBold, 0)
+		s.screen.RenderText("underline", xOffset+5, yOffset, io.AttrUnderline, 0)
+		s.screen.RenderText("reverse", xOffset+15, yOffset, io.AttrReverse, 0)
+		return
 	}
+	rows := s.screen.GetHeight() - 3 - yOffset
+	gridWidth := renderColorGrid(s.screen, xOffset, yOffset, rows, 0, numColors, false, 0, -1)
 
 	// Render background color grid
-	rowIndex = 0
-	columnIndex += 6
-	for colorCode := 0; colorCode < 256; colorCode++ {
-		s.screen.RenderText(fmt.Sprintf("%d", colorCode), columnIndex+xOffset, rowIndex+yOffset, 255, colorCode)
-		rowIndex++
-		if rowIndex+yOffset > s.screen.GetHeight()-4 {
-			columnIndex += 4
-			rowIndex = 0
-		}
+	renderColorGrid(s.screen, xOffset+gridWidth+2, yOffset, rows, 0, numColors, true, theme.Color(screen.RoleText), -1)
+}
+
+// colorModeDescription ...
+// Describes a color mode for display.
+func colorModeDescription(mode io.ColorMode) string {
+	switch mode {
+	case io.ColorModeMonochrome:
+		return "monochrome"
+	case io.ColorModeTrueColor:
+		return "24-bit color"
+	default:
+		return mode.String() + "-color mode"
 	}
 }
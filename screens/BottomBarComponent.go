@@ -18,32 +18,106 @@
 package screens
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
 	"github.com/stuartthompson/dailyvocab/io/screen"
+	"github.com/stuartthompson/dailyvocab/progress"
 )
 
-// borderColor ...
-// The border color for this component.
-const borderColor = 54 // Purple (#5f0087)
+// MessageTimeout ...
+// How long messages are shown in the bottom bar.
+const MessageTimeout = 5 * time.Second
+
+// KeyHint ...
+// Describes a key binding shown in the bottom bar.
+type KeyHint struct {
+	Key         string // Key (or keys) to press
+	Description string // What the key does
+}
 
 // BottomBarComponent ...
+// Shows key bindings for the active screen, study status and transient messages.
 type BottomBarComponent struct {
-	screen *screen.Screen
-	config *configuration.AppConfig
+	screen           *screen.Screen
+	config           *configuration.AppConfig
+	now              func() time.Time // Gets the current time
+	screenKeyHints   []KeyHint        // Keys for the active screen
+	globalKeyHints   []KeyHint        // Keys available on every screen
+	message          string           // Message being shown (empty for none)
+	messageRole      screen.Role      // Role used to color the message
+	messageExpiresAt time.Time        // When the message stops being shown
 }
 
 // NewBottomBarComponent ...
 // Instantiates a new bottom bar component.
-func NewBottomBarComponent(config *configuration.AppConfig, viewport *screen.Viewport) *BottomBarComponent {
-	screenStyle := &screen.Style{ShowBorder: true, BorderColor: borderColor}
+func NewBottomBarComponent(config *configuration.AppConfig, viewport *screen.Viewport, theme *screen.Theme, now func() time.Time) *BottomBarComponent {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
 	screen := screen.NewScreen(viewport, screenStyle)
-	return &BottomBarComponent{screen: screen, config: config}
+	return &BottomBarComponent{screen: screen, config: config, now: now}
+}
+
+// SetKeyHints ...
+// Sets the key bindings to show for the active screen and for every screen.
+func (c *BottomBarComponent) SetKeyHints(screenKeyHints []KeyHint, globalKeyHints []KeyHint) {
+	c.screenKeyHints = screenKeyHints
+	c.globalKeyHints = globalKeyHints
+}
+
+// ShowMessage ...
+// Shows a message (colored for a role) until it expires.
+func (c *BottomBarComponent) ShowMessage(message string, role screen.Role) {
+	c.message = message
+	c.messageRole = role
+	c.messageExpiresAt = c.now().Add(MessageTimeout)
 }
 
 // Render ...
 // Renders the bottom bar component.
 func (c *BottomBarComponent) Render() {
 	c.screen.Clear()
+	theme := c.screen.GetTheme()
+	now := c.now()
+
+	// Render key hints
+	c.renderKeyHints(c.screenKeyHints, 0)
+	c.renderKeyHints(c.globalKeyHints, 1)
+
+	// Render status
+	summary := progress.Compute(c.config, now)
+	days := "days"
+	if summary.CurrentStreak == 1 {
+		days = "day"
+	}
+	status := fmt.Sprintf("Streak: %d %s  Best: %d", summary.CurrentStreak, days, summary.LongestStreak)
+	if done, total := summary.Today.GoalProgress(summary.Goal); total > 0 {
+		status += fmt.Sprintf("  Goal: %d/%d", done, total)
+	}
+	status += fmt.Sprintf("  Reviews due: %d", c.config.DueReviews(now))
+	if c.config.StreakFreezes {
+		status += fmt.Sprintf("  Freezes: %d", summary.Freezes)
+	}
+	c.screen.RenderText(status, 1, 3, theme.Color(screen.RoleText), 0)
+	languagePair := c.config.StudyPair().String()
+	c.screen.RenderAlignedTextInColumn(languagePair, 1+io.TextWidth(status)+2, 3, c.screen.GetContentWidth()-io.TextWidth(status)-4, screen.AlignRight, theme.Color(screen.RoleMuted), 0)
+
+	// Render message (until it expires)
+	if c.message != "" && now.Before(c.messageExpiresAt) {
+		c.screen.RenderText(c.message, 1, 4, theme.Color(c.messageRole), 0)
+	}
+}
 
-	c.screen.RenderText("Bottom Bar", 0, 0, 255, 0)
+// renderKeyHints ...
+// Renders a row of key hints.
+func (c *BottomBarComponent) renderKeyHints(hints []KeyHint, y int) {
+	theme := c.screen.GetTheme()
+	x := 1
+	for _, hint := range hints {
+		c.screen.RenderText(hint.Key, x, y, theme.Color(screen.RoleTitle), 0)
+		x += io.TextWidth(hint.Key) + 1
+		c.screen.RenderText(hint.Description, x, y, theme.Color(screen.RoleMuted), 0)
+		x += io.TextWidth(hint.Description) + 3
+	}
 }
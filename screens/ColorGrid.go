@@ -0,0 +1,59 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// colorGridColumnWidth ...
+// Width of each column in a color grid.
+const colorGridColumnWidth = 4
+
+// renderColorGrid ...
+// Renders color codes from first up to (but not including) last in columns of the given number of rows. Each code is shown in its color, or on its
+// color when background is set (with text in textColor). The cursor code is shown reversed (-1 for none).
+// Returns the width of the grid.
+func renderColorGrid(s *screen.Screen, x int, y int, rows int, first int, last int, background bool, textColor int, cursor int) int {
+	if rows < 1 {
+		rows = 1
+	}
+
+	rowIndex := 0
+	columnIndex := 0
+	for colorCode := first; colorCode < last; colorCode++ {
+		fgColor, bgColor := colorCode, 0
+		if background {
+			fgColor, bgColor = textColor, colorCode
+		}
+		if colorCode == cursor {
+			fgColor |= io.AttrReverse
+		}
+		s.RenderText(fmt.Sprintf("%d", colorCode), x+columnIndex, y+rowIndex, fgColor, bgColor)
+		rowIndex++
+		if rowIndex >= rows {
+			columnIndex += colorGridColumnWidth
+			rowIndex = 0
+		}
+	}
+
+	return columnIndex + colorGridColumnWidth
+}
@@ -18,6 +18,10 @@
 package screens
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/stuartthompson/dailyvocab/app"
 	"github.com/stuartthompson/dailyvocab/configuration"
 	"github.com/stuartthompson/dailyvocab/io/screen"
 )
@@ -30,8 +34,8 @@ type ConfigScreen struct {
 
 // NewConfigScreen ...
 // Instantiates a new config screen.
-func NewConfigScreen(config *configuration.AppConfig, viewport *screen.Viewport) *ConfigScreen {
-	screenStyle := &screen.Style{ShowBorder: true, BorderColor: 100}
+func NewConfigScreen(config *configuration.AppConfig, viewport *screen.Viewport, theme *screen.Theme) *ConfigScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
 	screen := screen.NewScreen(viewport, screenStyle)
 	return &ConfigScreen{screen: screen, configuration: config}
 }
@@ -40,7 +44,33 @@ func NewConfigScreen(config *configuration.AppConfig, viewport *screen.Viewport)
 // Renders the config screen.
 func (s *ConfigScreen) Render() {
 	s.screen.Clear()
+	theme := s.screen.GetTheme()
+
+	s.screen.RenderText("Config", 1, 1, theme.Color(screen.RoleTitle), 0)
+	pair := s.configuration.StudyPair()
+	s.screen.RenderText(fmt.Sprintf("Known language: %s (%s)", app.LanguageName(pair.Known), pair.Known), 1, 3, theme.Color(screen.RoleText), 0)
+	s.screen.RenderText("Learning: "+pair.LearningNames(), 1, 4, theme.Color(screen.RoleText), 0)
+	s.screen.RenderText("Theme: "+theme.Name, 1, 5, theme.Color(screen.RoleText), 0)
+	s.screen.RenderText(fmt.Sprintf("Day starts: %02d:00 (%s)", s.configuration.DayStartHour, s.configuration.Location()), 1, 6, theme.Color(screen.RoleText), 0)
+	pronounce := "not set"
+	if len(s.configuration.PronounceCommand) > 0 {
+		pronounce = strings.Join(s.configuration.PronounceCommand, " ")
+	}
+	s.screen.RenderText("Pronounce command: "+pronounce, 1, 7, theme.Color(screen.RoleText), 0)
+	daily := describeFilter(s.configuration.DailyFilter)
+	if s.configuration.DailyFilterUntil != "" && !s.configuration.DailyFilter.IsEmpty() {
+		daily += " until " + s.configuration.DailyFilterUntil
+	}
+	s.screen.RenderText("Daily words: "+daily, 1, 8, theme.Color(screen.RoleText), 0)
+	s.screen.RenderText("Quiz words: "+describeFilter(s.configuration.QuizFilter), 1, 9, theme.Color(screen.RoleText), 0)
+}
+
+// describeFilter ...
+// Describes the words a filter selects.
+func describeFilter(filter app.WordFilter) string {
+	if filter.IsEmpty() {
+		return "all"
+	}
 
-	s.screen.RenderText("Config", 1, 1, 255, 0)
-	s.screen.RenderText("Default language: "+s.configuration.DefaultLanguage, 1, 3, 255, 0)
+	return filter.String()
 }
@@ -18,28 +18,98 @@
 package screens
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
 	"github.com/stuartthompson/dailyvocab/configuration"
 	"github.com/stuartthompson/dailyvocab/io/screen"
+	"github.com/stuartthompson/dailyvocab/progress"
 )
 
 // DailyWordScreen ...
 type DailyWordScreen struct {
 	screen        *screen.Screen
 	configuration *configuration.AppConfig
+	vocabulary    *app.Vocabulary // The word list the daily word is chosen from
+	day           time.Time       // The study day whose word is shown
 }
 
 // NewDailyWordScreen ...
 // Instantiates a new daily word screen.
-func NewDailyWordScreen(config *configuration.AppConfig, viewport *screen.Viewport) *DailyWordScreen {
-	screenStyle := &screen.Style{ShowBorder: true, BorderColor: 100}
+func NewDailyWordScreen(config *configuration.AppConfig, vocabulary *app.Vocabulary, viewport *screen.Viewport, theme *screen.Theme) *DailyWordScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
 	screen := screen.NewScreen(viewport, screenStyle)
-	return &DailyWordScreen{screen: screen, configuration: config}
+	return &DailyWordScreen{screen: screen, configuration: config, vocabulary: vocabulary}
+}
+
+// SetDay ...
+// Sets the study day whose word is shown.
+func (s *DailyWordScreen) SetDay(day time.Time) {
+	s.day = day
+}
+
+// GetWordID ...
+// Gets the id of the word of the day (or zero if there are no words).
+func (s *DailyWordScreen) GetWordID() int {
+	word := s.vocabulary.WordForDay(s.day, s.configuration.StudyPair(), s.configuration.DailyWordFilter(s.day))
+	if word == nil {
+		return 0
+	}
+
+	return word.ID
 }
 
 // Render ...
 // Renders the daily word screen.
 func (s *DailyWordScreen) Render() {
 	s.screen.Clear()
-	s.screen.RenderText("Word of the Day", 1, 1, 255, 0)
-	s.screen.RenderText("English: ", 1, 3, 255, 0)
+	theme := s.screen.GetTheme()
+	s.screen.RenderText("Word of the Day", 1, 1, theme.Color(screen.RoleTitle), 0)
+	s.screen.RenderAlignedTextInColumn(s.day.Format("Monday, 2 January 2006"), 1, 1, s.screen.GetContentWidth()-2, screen.AlignRight, theme.Color(screen.RoleMuted), 0)
+
+	filter := s.configuration.DailyWordFilter(s.day)
+	word := s.vocabulary.WordForDay(s.day, s.configuration.StudyPair(), filter)
+	if word == nil && !filter.IsEmpty() {
+		s.screen.RenderText("No words to study match "+filter.String(), 1, 3, theme.Color(screen.RoleError), 0)
+		return
+	}
+	if word == nil {
+		s.screen.RenderText("No words to study in "+s.configuration.StudyPair().String(), 1, 3, theme.Color(screen.RoleError), 0)
+		return
+	}
+
+	// Render the word, with a checkmark once it has been learned
+	s.screen.RenderText(knownWord(word, s.configuration.StudyPair()), 1, 3, theme.Color(screen.RoleTitle), 0)
+	if buildViewedWordsMap(s.configuration.ViewedWords)[word.ID] != "" {
+		s.screen.RenderText("✓ learned", s.screen.GetContentWidth()-10, 3, theme.Color(screen.RoleSuccess), 0)
+	}
+	y := renderTranslations(s.screen, pairTranslations(word, s.configuration.StudyPair()), 5, s.configuration.RomanizationUnder)
+
+	// Render an example sentence in each language being learned
+	if learning := word.StudyTranslations(s.configuration.StudyPair()); hasExamples(learning) {
+		renderExamples(s.screen, learning, y+1, 1)
+	}
+
+	s.renderGoal(s.screen.GetContentHeight() - 1)
+}
+
+// renderGoal ...
+// Renders the day's progress toward the daily goal.
+func (s *DailyWordScreen) renderGoal(y int) {
+	theme := s.screen.GetTheme()
+	today := progress.History(s.configuration)[s.day]
+	goal := s.configuration.DailyGoal
+	if _, total := today.GoalProgress(goal); total == 0 {
+		s.screen.RenderText(fmt.Sprintf("Today: %d new, %d reviewed", today.NewWords, today.Reviews), 1, y, theme.Color(screen.RoleMuted), 0)
+		return
+	}
+
+	str := fmt.Sprintf("Today's goal: %d/%d new words  %d/%d reviews", today.NewWords, goal.NewWords, today.Reviews, goal.Reviews)
+	color := theme.Color(screen.RoleText)
+	if today.MeetsGoal(goal) {
+		str += "  ✓"
+		color = theme.Color(screen.RoleSuccess)
+	}
+	s.screen.RenderText(str, 1, y, color, 0)
 }
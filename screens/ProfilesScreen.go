@@ -0,0 +1,176 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+	"github.com/stuartthompson/dailyvocab/progress"
+)
+
+// ProfilesScreen ...
+// Lists the profiles sharing the installation (ranked by progress) so the user can switch to one or
+// create a new one.
+type ProfilesScreen struct {
+	screen        *screen.Screen
+	configuration *configuration.AppConfig
+	standings     []progress.Standing // Profiles, ranked by progress
+	selectedIndex int                 // Index of the selected profile
+	isNaming      bool                // Whether the user is entering the name of a new profile
+	name          string              // Name of the new profile
+	message       string              // Message about the last attempt to create a profile
+}
+
+// NewProfilesScreen ...
+// Instantiates a new profiles screen.
+func NewProfilesScreen(config *configuration.AppConfig, viewport *screen.Viewport, theme *screen.Theme) *ProfilesScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
+	screen := screen.NewScreen(viewport, screenStyle)
+	return &ProfilesScreen{screen: screen, configuration: config}
+}
+
+// SetStandings ...
+// Sets the profiles to list, selecting the current profile.
+func (s *ProfilesScreen) SetStandings(standings []progress.Standing) {
+	s.standings = standings
+	s.selectedIndex = 0
+	for i, standing := range standings {
+		if standing.Profile == s.configuration.ProfileName() {
+			s.selectedIndex = i
+		}
+	}
+	s.isNaming = false
+	s.message = ""
+}
+
+// Render ...
+// Renders the profiles screen.
+func (s *ProfilesScreen) Render() {
+	s.screen.Clear()
+	theme := s.screen.GetTheme()
+
+	s.screen.RenderText("Profiles", 1, 0, theme.Color(screen.RoleTitle), 0)
+	s.screen.RenderText(fmt.Sprintf("Studying as %s.", s.configuration.ProfileName()), 1, 2, theme.Color(screen.RoleText), 0)
+
+	for i, standing := range s.standings {
+		y := 4 + i
+		if y >= s.screen.GetContentHeight()-2 {
+			break
+		}
+		if standing.Profile == s.configuration.ProfileName() {
+			s.screen.RenderText("✓", 1, y, theme.Color(screen.RoleSuccess), 0)
+		}
+		bgColor := 0
+		if i == s.selectedIndex {
+			bgColor = theme.Color(screen.RoleHighlight)
+		}
+		str := fmt.Sprintf("%d. %-12s %4d learned  streak %d (best %d)", i+1, standing.Profile, standing.WordsLearned, standing.CurrentStreak, standing.LongestStreak)
+		s.screen.RenderAlignedTextInColumn(str, 3, y, s.screen.GetContentWidth()-4, screen.AlignLeft, theme.Color(screen.RoleText), bgColor)
+	}
+
+	// Render the name of the new profile being entered
+	bottom := s.screen.GetContentHeight() - 1
+	if s.message != "" {
+		s.screen.RenderText(s.message, 1, bottom-1, theme.Color(screen.RoleError), 0)
+	}
+	if s.isNaming {
+		s.screen.RenderText("New profile: "+s.name+"_", 1, bottom, theme.Color(screen.RoleText), 0)
+	}
+}
+
+// SelectNext ...
+// Moves the selection to the next profile.
+func (s *ProfilesScreen) SelectNext() {
+	if s.selectedIndex < len(s.standings)-1 {
+		s.selectedIndex++
+	}
+}
+
+// SelectPrevious ...
+// Moves the selection to the previous profile.
+func (s *ProfilesScreen) SelectPrevious() {
+	if s.selectedIndex > 0 {
+		s.selectedIndex--
+	}
+}
+
+// GetSelectedProfile ...
+// Gets the name of the selected profile (or an empty string if there are no profiles).
+func (s *ProfilesScreen) GetSelectedProfile() string {
+	if s.selectedIndex >= len(s.standings) {
+		return ""
+	}
+
+	return s.standings[s.selectedIndex].Profile
+}
+
+// StartNaming ...
+// Starts entering the name of a new profile.
+func (s *ProfilesScreen) StartNaming() {
+	s.isNaming = true
+	s.name = ""
+}
+
+// IsNaming ...
+// Determines whether the user is entering the name of a new profile.
+func (s *ProfilesScreen) IsNaming() bool {
+	return s.isNaming
+}
+
+// TypeNameCharacter ...
+// Adds a character to the name being entered.
+func (s *ProfilesScreen) TypeNameCharacter(ch rune) {
+	s.name += string(ch)
+}
+
+// DeleteNameCharacter ...
+// Removes the last character from the name being entered.
+func (s *ProfilesScreen) DeleteNameCharacter() {
+	runes := []rune(s.name)
+	if len(runes) > 0 {
+		s.name = string(runes[:len(runes)-1])
+	}
+}
+
+// CancelNaming ...
+// Stops entering a name without creating a profile.
+func (s *ProfilesScreen) CancelNaming() {
+	s.isNaming = false
+	s.message = ""
+}
+
+// NewProfileName ...
+// Gets the entered name of the new profile, or an error (which is shown) if it cannot be used.
+func (s *ProfilesScreen) NewProfileName() (string, error) {
+	if err := configuration.ValidateProfileName(s.name); err != nil {
+		s.message = "Use letters, digits, - and _ in profile names."
+		return "", err
+	}
+	for _, standing := range s.standings {
+		if standing.Profile == s.name {
+			s.message = fmt.Sprintf("Profile %s already exists.", s.name)
+			return "", fmt.Errorf("profile %q already exists", s.name)
+		}
+	}
+	s.isNaming = false
+	s.message = ""
+
+	return s.name, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"testing"
+
+	"github.com/stuartthompson/dailyvocab/io/screen"
+	"github.com/stuartthompson/dailyvocab/progress"
+)
+
+// testStandings ...
+// Creates standings of profiles for use in tests.
+func testStandings() []progress.Standing {
+	return []progress.Standing{
+		{Profile: "alice", WordsLearned: 12, CurrentStreak: 4, LongestStreak: 9},
+		{Profile: "default", WordsLearned: 1, CurrentStreak: 1, LongestStreak: 1},
+		{Profile: "bob", WordsLearned: 0},
+	}
+}
+
+func TestProfilesScreenNewProfileName(t *testing.T) {
+	profilesScreen := NewProfilesScreen(testConfiguration(), screen.NewViewport(0, 0, 80, 17), screen.DarkTheme)
+	profilesScreen.SetStandings(testStandings())
+	if profile := profilesScreen.GetSelectedProfile(); profile != "default" {
+		t.Errorf("Expected the current profile to be selected, got %s", profile)
+	}
+
+	for _, name := range []string{"bob", "carol smith", ""} {
+		profilesScreen.StartNaming()
+		for _, ch := range name {
+			profilesScreen.TypeNameCharacter(ch)
+		}
+		if _, err := profilesScreen.NewProfileName(); err == nil || !profilesScreen.IsNaming() {
+			t.Errorf("Expected profile name %q to be rejected", name)
+		}
+	}
+
+	profilesScreen.StartNaming()
+	profilesScreen.TypeNameCharacter('d')
+	profilesScreen.TypeNameCharacter('x')
+	profilesScreen.DeleteNameCharacter()
+	if name, err := profilesScreen.NewProfileName(); err != nil || name != "d" || profilesScreen.IsNaming() {
+		t.Errorf("Expected new profile d, got %q (%v)", name, err)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// quizLength ...
+// The most questions asked in a quiz.
+const quizLength = 10
+
+// QuizScreen ...
+// Quizzes the user on learned words (those due for review first), asking for each word in the known
+// language or in a language being learned (or, in other kinds of quiz, for its article or plural or to fill it
+// in to an example sentence).
+type QuizScreen struct {
+	screen        *screen.Screen
+	configuration *configuration.AppConfig
+	vocabulary    *app.Vocabulary
+	now           func() time.Time   // Gets the current time
+	questions     []app.QuizQuestion // The questions in the quiz
+	index         int                // Index of the current question
+	answer        string             // The answer being entered
+	answered      bool               // Whether the current question has been answered
+	correct       bool               // Whether the current question was answered correctly
+	score         int                // Number of questions answered correctly
+}
+
+// NewQuizScreen ...
+// Instantiates a new quiz screen.
+func NewQuizScreen(config *configuration.AppConfig, vocabulary *app.Vocabulary, viewport *screen.Viewport, theme *screen.Theme, now func() time.Time) *QuizScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
+	screen := screen.NewScreen(viewport, screenStyle)
+	return &QuizScreen{screen: screen, configuration: config, vocabulary: vocabulary, now: now}
+}
+
+// Start ...
+// Starts a new quiz of a kind on the learned words that match a filter, earliest due for review first. Returns
+// the number of questions.
+func (s *QuizScreen) Start(kind app.QuizKind, filter app.WordFilter) int {
+	viewedWords := append([]configuration.ViewedWord(nil), s.configuration.ViewedWords...)
+	sort.SliceStable(viewedWords, func(i, j int) bool {
+		a, _ := s.configuration.ReviewTime(viewedWords[i])
+		b, _ := s.configuration.ReviewTime(viewedWords[j])
+		return a.Before(b)
+	})
+	var words []*app.Word
+	for _, viewed := range viewedWords {
+		if word := s.vocabulary.GetWord(viewed.ID); word != nil && filter.Matches(word) {
+			words = append(words, word)
+		}
+	}
+
+	switch kind {
+	case app.GrammarQuiz:
+		s.questions = app.BuildGrammarQuiz(words, s.configuration.StudyPair())
+	case app.ClozeQuiz:
+		s.questions = app.BuildClozeQuiz(words, s.configuration.StudyPair())
+	default:
+		s.questions = app.BuildQuiz(words, s.configuration.StudyPair())
+	}
+	if len(s.questions) > quizLength {
+		s.questions = s.questions[:quizLength]
+	}
+	s.index, s.score = 0, 0
+	s.answer, s.answered = "", false
+
+	return len(s.questions)
+}
+
+// Current ...
+// Gets the current question (or nil once the quiz is finished).
+func (s *QuizScreen) Current() *app.QuizQuestion {
+	if s.index >= len(s.questions) {
+		return nil
+	}
+
+	return &s.questions[s.index]
+}
+
+// IsAnswering ...
+// Determines whether the user is entering the answer to a question.
+func (s *QuizScreen) IsAnswering() bool {
+	return s.Current() != nil && !s.answered
+}
+
+// TypeAnswerCharacter ...
+// Adds a character to the answer being entered.
+func (s *QuizScreen) TypeAnswerCharacter(ch rune) {
+	s.answer += string(ch)
+}
+
+// DeleteAnswerCharacter ...
+// Removes the last character from the answer being entered.
+func (s *QuizScreen) DeleteAnswerCharacter() {
+	runes := []rune(s.answer)
+	if len(runes) > 0 {
+		s.answer = string(runes[:len(runes)-1])
+	}
+}
+
+// Submit ...
+// Checks the answer to the current question. Returns whether it was correct.
+func (s *QuizScreen) Submit() bool {
+	question := s.Current()
+	if question == nil || s.answered {
+		return false
+	}
+	s.answered = true
+	s.correct = question.IsCorrect(s.answer)
+	if s.correct {
+		s.score++
+	}
+
+	return s.correct
+}
+
+// Next ...
+// Moves on to the next question. Returns false if the quiz is finished.
+func (s *QuizScreen) Next() bool {
+	if s.index < len(s.questions) {
+		s.index++
+	}
+	s.answer, s.answered = "", false
+
+	return s.Current() != nil
+}
+
+// Render ...
+// Renders the quiz screen.
+func (s *QuizScreen) Render() {
+	s.screen.Clear()
+	theme := s.screen.GetTheme()
+	s.screen.RenderText("Quiz", 1, 1, theme.Color(screen.RoleTitle), 0)
+
+	question := s.Current()
+	if question == nil {
+		s.screen.RenderText(fmt.Sprintf("Quiz complete: %d/%d correct", s.score, len(s.questions)), 1, 3, theme.Color(screen.RoleSuccess), 0)
+		return
+	}
+	s.screen.RenderAlignedTextInColumn(fmt.Sprintf("Question %d of %d", s.index+1, len(s.questions)), 1, 1, s.screen.GetContentWidth()-2, screen.AlignRight, theme.Color(screen.RoleMuted), 0)
+
+	// Render the word shown and what is asked for
+	s.screen.RenderText(questionText(question), 1, 3, theme.Color(screen.RoleText), 0)
+	s.screen.RenderText(question.Prompt.Native, 1, 5, theme.Accent(question.Prompt.LanguageCode), 0)
+	hint := question.Prompt.Anglicized
+	if question.Hint != "" {
+		hint = question.Hint
+	}
+	if hint != "" {
+		s.screen.RenderText(hint, 1, 6, theme.Color(screen.RoleMuted), 0)
+	}
+
+	// Render the answer being entered, then whether it was correct
+	if !s.answered {
+		s.screen.RenderText("Answer: "+s.answer+"_", 1, 8, theme.Color(screen.RoleText), 0)
+		return
+	}
+	s.screen.RenderText("Answer: "+s.answer, 1, 8, theme.Color(screen.RoleText), 0)
+	if s.correct {
+		s.screen.RenderText("✓ Correct", 1, 10, theme.Color(screen.RoleSuccess), 0)
+		return
+	}
+	expected := question.Answer.Native
+	if question.Answer.Anglicized != "" {
+		expected += " (" + question.Answer.Anglicized + ")"
+	}
+	s.screen.RenderText("✗ The answer is "+expected, 1, 10, theme.Color(screen.RoleError), 0)
+}
+
+// questionText ...
+// Gets the text that asks a question (e.g. "Translate into French:").
+func questionText(question *app.QuizQuestion) string {
+	switch question.Direction {
+	case app.AskArticle:
+		return fmt.Sprintf("Which article goes with this %s word?", app.LanguageName(question.Prompt.LanguageCode))
+	case app.AskPlural:
+		return fmt.Sprintf("What is the plural of this %s word?", app.LanguageName(question.Prompt.LanguageCode))
+	case app.FillBlank:
+		return fmt.Sprintf("Fill in the blank (%s):", app.LanguageName(question.Prompt.LanguageCode))
+	}
+
+	return fmt.Sprintf("Translate into %s:", app.LanguageName(question.Answer.LanguageCode))
+}
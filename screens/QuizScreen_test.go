@@ -0,0 +1,101 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// newTestQuizScreen ...
+// Creates a quiz screen on words 1 to 3 (learned in that order) in the test vocabulary.
+func newTestQuizScreen(viewport *screen.Viewport, studyLanguages ...string) *QuizScreen {
+	config := testConfiguration()
+	config.StudyLanguages = studyLanguages
+	config.ViewedWords = []configuration.ViewedWord{
+		{ID: 1, MarkedViewedAt: "2018-05-29T09:00:00Z"},
+		{ID: 2, MarkedViewedAt: "2018-05-30T09:00:00Z"},
+		{ID: 3, MarkedViewedAt: "2018-05-31T09:00:00Z"},
+	}
+	now := func() time.Time { return time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC) }
+
+	return NewQuizScreen(config, testVocabulary(), viewport, screen.DarkTheme, now)
+}
+
+func TestQuizScreenAsksInBothDirections(t *testing.T) {
+	quizScreen := newTestQuizScreen(screen.NewViewport(0, 0, 80, 17), "fr", "el")
+	if n := quizScreen.Start(app.VocabularyQuiz, app.WordFilter{}); n != 3 {
+		t.Fatalf("Expected a question for each learned word, got %d", n)
+	}
+
+	expected := []app.QuizQuestion{
+		{WordID: 1, Direction: app.Recognize, Prompt: testVocabulary().Words[0].Translations[1], Answer: app.LocalizedWord{LanguageCode: "en-us", Native: "hello"}},
+		{WordID: 2, Direction: app.Recall, Prompt: app.LocalizedWord{LanguageCode: "en-us", Native: "goodbye"}, Answer: app.LocalizedWord{LanguageCode: "fr", Native: "au revoir"}},
+		{WordID: 3, Direction: app.Recognize, Prompt: testVocabulary().Words[2].Translations[1], Answer: app.LocalizedWord{LanguageCode: "en-us", Native: "morning"}},
+	}
+	answers := []string{"Hello ", "au revoi", "morning"}
+	for i, question := range expected {
+		if current := quizScreen.Current(); current == nil || !reflect.DeepEqual(*current, question) {
+			t.Fatalf("Expected question %+v, got %+v", question, current)
+		}
+		for _, ch := range answers[i] {
+			quizScreen.TypeAnswerCharacter(ch)
+		}
+		if correct := quizScreen.Submit(); correct != (i != 1) {
+			t.Errorf("Expected answer %q to question %d to be correct: %v", answers[i], i+1, i != 1)
+		}
+		quizScreen.Next()
+	}
+	if quizScreen.Current() != nil || quizScreen.score != 2 {
+		t.Errorf("Expected the quiz to finish with 2 correct answers, got %d", quizScreen.score)
+	}
+}
+
+func TestQuizScreenAsksForArticlesAndPlurals(t *testing.T) {
+	quizScreen := newTestQuizScreen(screen.NewViewport(0, 0, 80, 17), "fr", "el")
+	if n := quizScreen.Start(app.GrammarQuiz, app.WordFilter{}); n != 1 {
+		t.Fatalf("Expected a question for the only word with an article, got %d", n)
+	}
+	question := quizScreen.Current()
+	if question.WordID != 3 || question.Direction != app.AskArticle || question.Answer.Native != "το" {
+		t.Errorf("Unexpected question %+v", question)
+	}
+	if questionText(question) != "Which article goes with this Greek word?" {
+		t.Errorf("Unexpected question text %q", questionText(question))
+	}
+	for _, ch := range "Το" {
+		quizScreen.TypeAnswerCharacter(ch)
+	}
+	if !quizScreen.Submit() {
+		t.Error("Expected the article to be accepted")
+	}
+}
+
+func TestQuizAcceptsAnglicizedAnswers(t *testing.T) {
+	question := app.QuizQuestion{Answer: app.LocalizedWord{LanguageCode: "el", Native: "πρωί", Anglicized: "proí"}}
+	for answer, correct := range map[string]bool{"πρωί": true, "Proí": true, "proi": false, "": false} {
+		if question.IsCorrect(answer) != correct {
+			t.Errorf("Expected answer %q to be correct: %v", answer, correct)
+		}
+	}
+}
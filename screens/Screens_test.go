@@ -0,0 +1,194 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// testNow ...
+// The time used by tests, the day after the test configuration's word was viewed.
+func testNow() time.Time {
+	return time.Date(2018, time.June, 2, 12, 0, 0, 0, time.UTC)
+}
+
+// testDay ...
+// The day the test configuration's word was viewed.
+var testDay = time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+// pageVocabulary ...
+// Creates a vocabulary with more words than fit on a page.
+func pageVocabulary() *app.Vocabulary {
+	vocabulary := &app.Vocabulary{}
+	for id := 1; id <= 20; id++ {
+		vocabulary.Words = append(vocabulary.Words, app.Word{ID: id, Translations: []app.LocalizedWord{
+			{LanguageCode: "en-us", Native: fmt.Sprintf("word %d", id)},
+			{LanguageCode: "fr", Native: fmt.Sprintf("mot %d", id)},
+		}})
+	}
+
+	return vocabulary
+}
+
+// screenSnapshots ...
+// The screens and components that are snapshotted, each rendered with the dark theme.
+var screenSnapshots = []struct {
+	name   string
+	sizes  []snapshotSize
+	render func(viewport *screen.Viewport)
+}{
+	{"AboutScreen", screenSizes, func(viewport *screen.Viewport) {
+		NewAboutScreen(testConfiguration(), viewport, screen.DarkTheme).Render()
+	}},
+	{"AboutScreen_16", screenSizes[1:2], func(viewport *screen.Viewport) {
+		io.SetColorMode(io.ColorMode16)
+		NewAboutScreen(testConfiguration(), viewport, screen.DarkTheme).Render()
+	}},
+	{"AboutScreen_monochrome", screenSizes[1:2], func(viewport *screen.Viewport) {
+		io.SetColorMode(io.ColorModeMonochrome)
+		NewAboutScreen(testConfiguration(), viewport, screen.DarkTheme).Render()
+	}},
+	{"BottomBarComponent", []snapshotSize{{40, 7}, {80, 7}, {120, 7}}, func(viewport *screen.Viewport) {
+		bar := NewBottomBarComponent(testConfiguration(), viewport, screen.DarkTheme, testNow)
+		bar.SetKeyHints([]KeyHint{{Key: "↑↓", Description: "select"}, {Key: "Enter", Description: "open"}}, []KeyHint{{Key: "q", Description: "quit"}})
+		bar.ShowMessage("Word marked as learned", screen.RoleSuccess)
+		bar.Render()
+	}},
+	{"BottomBarComponent_expired", []snapshotSize{{80, 7}}, func(viewport *screen.Viewport) {
+		now := testNow()
+		bar := NewBottomBarComponent(testConfiguration(), viewport, screen.DarkTheme, func() time.Time { return now })
+		bar.ShowMessage("Saved", screen.RoleSuccess)
+		now = now.Add(MessageTimeout)
+		bar.Render()
+	}},
+	{"ConfigScreen", screenSizes, func(viewport *screen.Viewport) {
+		NewConfigScreen(testConfiguration(), viewport, screen.DarkTheme).Render()
+	}},
+	{"DailyWordScreen", screenSizes, func(viewport *screen.Viewport) {
+		dailyWordScreen := NewDailyWordScreen(testConfiguration(), testVocabulary(), viewport, screen.DarkTheme)
+		dailyWordScreen.SetDay(testDay)
+		dailyWordScreen.Render()
+	}},
+	{"DailyWordScreen_goal", screenSizes[1:2], func(viewport *screen.Viewport) {
+		config := testConfiguration()
+		config.DailyGoal = configuration.GoalConfig{NewWords: 1, Reviews: 2}
+		dailyWordScreen := NewDailyWordScreen(config, testVocabulary(), viewport, screen.DarkTheme)
+		dailyWordScreen.SetDay(testDay)
+		dailyWordScreen.Render()
+	}},
+	{"DailyWordScreen_examples", screenSizes[1:2], func(viewport *screen.Viewport) {
+		config := testConfiguration()
+		config.StudyLanguages = []string{"fr"}
+		dailyWordScreen := NewDailyWordScreen(config, testVocabulary(), viewport, screen.DarkTheme)
+		dailyWordScreen.SetDay(testDay.AddDate(0, 0, 1))
+		dailyWordScreen.Render()
+	}},
+	{"ProfilesScreen", screenSizes, func(viewport *screen.Viewport) {
+		profilesScreen := NewProfilesScreen(testConfiguration(), viewport, screen.DarkTheme)
+		profilesScreen.SetStandings(testStandings())
+		profilesScreen.SelectNext()
+		profilesScreen.StartNaming()
+		profilesScreen.TypeNameCharacter('c')
+		profilesScreen.Render()
+	}},
+	{"QuizScreen", screenSizes, func(viewport *screen.Viewport) {
+		quizScreen := newTestQuizScreen(viewport, "fr", "el")
+		quizScreen.Start(app.VocabularyQuiz, app.WordFilter{})
+		quizScreen.TypeAnswerCharacter('h')
+		quizScreen.Render()
+	}},
+	{"StatsScreen", screenSizes, func(viewport *screen.Viewport) {
+		config := testConfiguration()
+		config.MarkWordViewed(3, time.Date(2018, time.May, 20, 9, 0, 0, 0, time.UTC))
+		for _, at := range []string{"2018-05-28T09:00:00Z", "2018-05-28T10:00:00Z", "2018-05-29T09:00:00Z", "2018-05-31T09:00:00Z", "2018-05-31T10:00:00Z", "2018-05-31T11:00:00Z"} {
+			reviewedAt, _ := time.Parse(time.RFC3339, at)
+			config.RecordReview(3, reviewedAt)
+		}
+		NewStatsScreen(config, testVocabulary(), viewport, screen.DarkTheme, testNow).Render()
+	}},
+	{"StatsScreen_leaderboard", screenSizes[1:2], func(viewport *screen.Viewport) {
+		statsScreen := NewStatsScreen(testConfiguration(), testVocabulary(), viewport, screen.DarkTheme, testNow)
+		statsScreen.SetLeaderboard(testStandings())
+		statsScreen.Render()
+	}},
+	{"ThemeEditorScreen", screenSizes, func(viewport *screen.Viewport) {
+		editor := NewThemeEditorScreen(testConfiguration(), viewport, screen.DarkTheme)
+		editor.Edit(screen.DarkTheme)
+		editor.SelectNextRole()
+		editor.Render()
+	}},
+	{"WordDetailScreen", screenSizes, func(viewport *screen.Viewport) {
+		detailScreen := NewWordDetailScreen(testConfiguration(), testVocabulary(), viewport, screen.DarkTheme)
+		detailScreen.SetWord(1)
+		detailScreen.Render()
+	}},
+	{"WordDetailScreen_romanization_under", screenSizes[1:2], func(viewport *screen.Viewport) {
+		config := testConfiguration()
+		config.RomanizationUnder = true
+		detailScreen := NewWordDetailScreen(config, testVocabulary(), viewport, screen.DarkTheme)
+		detailScreen.SetWord(1)
+		detailScreen.Render()
+	}},
+	{"WordDetailScreen_long_rtl", screenSizes[:1], func(viewport *screen.Viewport) {
+		vocabulary := testVocabulary()
+		for i, translation := range vocabulary.Words[0].Translations {
+			if translation.LanguageCode == "he" {
+				vocabulary.Words[0].Translations[i].Native = "שלום לכם חברים יקרים ואהובים"
+			}
+		}
+		detailScreen := NewWordDetailScreen(testConfiguration(), vocabulary, viewport, screen.DarkTheme)
+		detailScreen.SetWord(1)
+		detailScreen.Render()
+	}},
+	{"WordDetailScreen_grammar", screenSizes[1:2], func(viewport *screen.Viewport) {
+		detailScreen := NewWordDetailScreen(testConfiguration(), testVocabulary(), viewport, screen.DarkTheme)
+		detailScreen.SetWord(3)
+		detailScreen.Render()
+	}},
+	{"WordDetailScreen_Missing", screenSizes[:1], func(viewport *screen.Viewport) {
+		NewWordDetailScreen(testConfiguration(), testVocabulary(), viewport, screen.DarkTheme).Render()
+	}},
+	{"WordListScreen", screenSizes, func(viewport *screen.Viewport) {
+		NewWordListScreen(testConfiguration(), testVocabulary(), viewport, screen.DarkTheme).Render()
+	}},
+	// A page has a row for each line of the content area below the header, with or without a border
+	{"WordListScreen_page_border_true", screenSizes[:1], func(viewport *screen.Viewport) {
+		NewWordListScreen(testConfiguration(), pageVocabulary(), viewport, screen.DarkTheme).Render()
+	}},
+	{"WordListScreen_page_border_false", screenSizes[:1], func(viewport *screen.Viewport) {
+		wordListScreen := NewWordListScreen(testConfiguration(), pageVocabulary(), viewport, screen.DarkTheme)
+		wordListScreen.screen = screen.NewScreen(viewport, &screen.Style{ShowBorder: false, Theme: screen.DarkTheme})
+		wordListScreen.Render()
+	}},
+}
+
+func TestScreensRender(t *testing.T) {
+	for _, snapshot := range screenSnapshots {
+		t.Run(snapshot.name, func(t *testing.T) {
+			defer io.SetColorMode(io.ColorMode256)
+			assertSnapshot(t, snapshot.name, snapshot.sizes, snapshot.render)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// update ...
+// Rewrites golden files with the current output instead of comparing against them (go test -update).
+var update = flag.Bool("update", false, "update golden files")
+
+// snapshotSize ...
+// The dimensions of a snapshot.
+type snapshotSize struct {
+	width  int
+	height int
+}
+
+// screenSizes ...
+// Sizes at which full screens are snapshotted.
+var screenSizes = []snapshotSize{{40, 12}, {80, 24}, {120, 40}}
+
+// assertSnapshot ...
+// Renders into an in-memory backend at each size and compares the result with golden files in testdata. The
+// golden files hold the text followed by its colors, so theme and color regressions are caught too.
+func assertSnapshot(t *testing.T, name string, sizes []snapshotSize, render func(viewport *screen.Viewport)) {
+	for _, size := range sizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			backend := io.NewMemoryBackend(size.width, size.height)
+			io.SetBackend(backend)
+			render(screen.NewViewport(0, 0, size.width, size.height))
+			io.Flush()
+			actual := backend.String() + "-- colors --\n" + backend.Colors()
+
+			goldenFile := filepath.Join("testdata", fmt.Sprintf("%s_%dx%d.golden", name, size.width, size.height))
+			if *update {
+				if err := os.MkdirAll("testdata", 0777); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(goldenFile, []byte(actual), 0666); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("Unable to read golden file (run go test -update to create it): %v", err)
+			}
+			if actual != string(expected) {
+				t.Errorf("Rendered output does not match %s.\nExpected:\n%s\nActual:\n%s", goldenFile, expected, actual)
+			}
+		})
+	}
+}
+
+// testConfiguration ...
+// Creates configuration for use in tests.
+func testConfiguration() *configuration.AppConfig {
+	config := &configuration.AppConfig{DefaultLanguage: "en-us", TimeZone: "UTC"}
+	config.MarkWordViewed(1, time.Date(2018, time.June, 1, 9, 0, 0, 0, time.UTC))
+
+	return config
+}
+
+// testVocabulary ...
+// Creates a small vocabulary for use in tests.
+func testVocabulary() *app.Vocabulary {
+	return &app.Vocabulary{Words: []app.Word{
+		{
+			ID:        1,
+			Tags:      []string{"greetings"},
+			Level:     "A1",
+			Frequency: 120,
+			Translations: []app.LocalizedWord{
+				{LanguageCode: "en-us", Native: "hello"},
+				{LanguageCode: "fr", Native: "bonjour", IPA: "bɔ̃.ʒuʁ", Examples: []app.Example{
+					{Sentence: "Je dis [bonjour] à tout le monde.", Translation: "I say hello to everyone."},
+				}},
+				{LanguageCode: "ja", Native: "こんにちは", Anglicized: "kon'nichiwa"},
+				{LanguageCode: "he", Native: "שלום", Anglicized: "shalom", Examples: []app.Example{
+					{Sentence: "אמרתי [שלום] לכולם", Translation: "I said hello to everyone"},
+				}},
+			},
+			Usage: []app.WordUsage{
+				{Type: "interjection", Meaning: "used to express a greeting, answer a telephone, or attract attention."},
+			},
+		},
+		{
+			ID: 2,
+			Translations: []app.LocalizedWord{
+				{LanguageCode: "en-us", Native: "goodbye"},
+				{LanguageCode: "fr", Native: "au revoir"},
+			},
+		},
+		{
+			ID: 3,
+			Translations: []app.LocalizedWord{
+				{LanguageCode: "en-us", Native: "morning"},
+				{LanguageCode: "el", Native: "πρωί", Anglicized: "proí", Gender: "neuter", Article: "το", Plural: "πρωινά", Forms: map[string]string{"genitive": "πρωιού", "genitive plural": "πρωινών"}},
+			},
+			Usage: []app.WordUsage{
+				{Type: "noun", Meaning: "the period of time between midnight and noon, especially from sunrise to noon."},
+			},
+		},
+	}}
+}
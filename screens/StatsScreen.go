@@ -0,0 +1,193 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+	"github.com/stuartthompson/dailyvocab/progress"
+)
+
+// Heatmap layout
+const (
+	heatmapLabelWidth  = 4  // Width of the weekday labels
+	heatmapColumnWidth = 2  // Width of each week
+	heatmapMaxWeeks    = 26 // Most weeks shown
+	statsSideWidth     = 18 // Width of the column beside the heatmap
+)
+
+// heatmapLevels ...
+// Characters showing increasing amounts of study in the heatmap (the first is for days without study).
+var heatmapLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// StatsScreen ...
+// Shows statistics about the study history, including a calendar heatmap of study days.
+type StatsScreen struct {
+	screen        *screen.Screen
+	configuration *configuration.AppConfig
+	vocabulary    *app.Vocabulary
+	now           func() time.Time    // Gets the current time
+	leaderboard   []progress.Standing // Standings of the profiles sharing the installation
+}
+
+// NewStatsScreen ...
+// Instantiates a new stats screen.
+func NewStatsScreen(config *configuration.AppConfig, vocabulary *app.Vocabulary, viewport *screen.Viewport, theme *screen.Theme, now func() time.Time) *StatsScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
+	screen := screen.NewScreen(viewport, screenStyle)
+	return &StatsScreen{screen: screen, configuration: config, vocabulary: vocabulary, now: now}
+}
+
+// Render ...
+// Renders the stats screen.
+func (s *StatsScreen) Render() {
+	s.screen.Clear()
+	theme := s.screen.GetTheme()
+	now := s.now()
+	stats := progress.ComputeStats(s.configuration, s.vocabulary, now)
+
+	s.screen.RenderText("Statistics", 1, 1, theme.Color(screen.RoleTitle), 0)
+
+	// Render summary
+	accuracy := "-"
+	if stats.QuizAccuracy != nil {
+		accuracy = fmt.Sprintf("%.0f%%", *stats.QuizAccuracy*100)
+	}
+	summary := fmt.Sprintf("Learned: %d  Reviews: %d  Quiz accuracy: %s  Avg. interval: %.1f days", stats.WordsLearned, stats.Reviews, accuracy, stats.AverageReviewInterval)
+	s.screen.RenderText(summary, 1, 3, theme.Color(screen.RoleText), 0)
+	s.screen.RenderText(fmt.Sprintf("Streak: %d  Longest: %d", stats.CurrentStreak, stats.LongestStreak), 1, 4, theme.Color(screen.RoleText), 0)
+
+	// Render heatmap, with coverage and words learned by month beside it
+	weeks := (s.screen.GetContentWidth() - 2 - heatmapLabelWidth - statsSideWidth - 2) / heatmapColumnWidth
+	if weeks > heatmapMaxWeeks {
+		weeks = heatmapMaxWeeks
+	}
+	if weeks < 1 {
+		weeks = 1
+	}
+	s.screen.RenderText("Study days", 1, 6, theme.Color(screen.RoleTitle), 0)
+	s.renderHeatmap(1, 7, weeks, now)
+	s.renderSide(1+heatmapLabelWidth+weeks*heatmapColumnWidth+2, 6, stats)
+}
+
+// SetLeaderboard ...
+// Sets the standings of the profiles, shown beside the heatmap when there is more than one profile.
+func (s *StatsScreen) SetLeaderboard(standings []progress.Standing) {
+	s.leaderboard = standings
+}
+
+// renderHeatmap ...
+// Renders a calendar of the last weeks (one column per week, one row per weekday starting on Monday),
+// shading each day by the amount studied, with a legend below it.
+func (s *StatsScreen) renderHeatmap(x int, y int, weeks int, now time.Time) {
+	theme := s.screen.GetTheme()
+	history := progress.History(s.configuration)
+	today := s.configuration.StudyDay(now)
+	weekday := (int(today.Weekday()) + 6) % 7
+	start := today.AddDate(0, 0, -weekday-7*(weeks-1))
+
+	for row, label := range []string{"Mon", "", "Wed", "", "Fri", "", "Sun"} {
+		s.screen.RenderText(label, x, y+row, theme.Color(screen.RoleMuted), 0)
+	}
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		offset := int(day.Sub(start).Hours()/24 + 0.5)
+		activity := history[day]
+		level := heatmapLevel(activity.NewWords + activity.Reviews)
+		color := theme.Color(screen.RoleSuccess)
+		if level == 0 {
+			color = theme.Color(screen.RoleMuted)
+		}
+		s.screen.RenderText(heatmapLevels[level], x+heatmapLabelWidth+(offset/7)*heatmapColumnWidth, y+offset%7, color, 0)
+	}
+
+	// Render legend
+	legendX := x + heatmapLabelWidth
+	s.screen.RenderText("Less", legendX, y+8, theme.Color(screen.RoleMuted), 0)
+	for level, ch := range heatmapLevels {
+		color := theme.Color(screen.RoleSuccess)
+		if level == 0 {
+			color = theme.Color(screen.RoleMuted)
+		}
+		s.screen.RenderText(ch, legendX+5+level, y+8, color, 0)
+	}
+	s.screen.RenderText("More", legendX+6+len(heatmapLevels), y+8, theme.Color(screen.RoleMuted), 0)
+}
+
+// renderSide ...
+// Renders the leaderboard, language coverage and words learned by month in a column.
+func (s *StatsScreen) renderSide(x int, y int, stats progress.Stats) {
+	theme := s.screen.GetTheme()
+	width := statsSideWidth
+
+	// Render the profiles ranked by words learned (when more than one profile shares the installation)
+	if len(s.leaderboard) > 1 {
+		s.screen.RenderText("Leaderboard", x, y, theme.Color(screen.RoleTitle), 0)
+		y++
+		for i, standing := range s.leaderboard {
+			color := theme.Color(screen.RoleText)
+			if standing.Profile == s.configuration.ProfileName() {
+				color = theme.Color(screen.RoleSuccess)
+			}
+			s.screen.RenderAlignedTextInColumn(fmt.Sprintf("%d. %s", i+1, standing.Profile), x, y, width-4, screen.AlignLeft, color, 0)
+			s.screen.RenderAlignedTextInColumn(fmt.Sprintf("%d", standing.WordsLearned), x+width-4, y, 4, screen.AlignRight, color, 0)
+			y++
+		}
+		y++
+	}
+
+	s.screen.RenderText("Languages", x, y, theme.Color(screen.RoleTitle), 0)
+	y++
+	for _, language := range stats.Languages {
+		count := fmt.Sprintf("%d/%d", language.Studied, language.Total)
+		s.screen.RenderAlignedTextInColumn(app.LanguageName(language.Language), x, y, width-io.TextWidth(count)-1, screen.AlignLeft, theme.Accent(language.Language), 0)
+		s.screen.RenderAlignedTextInColumn(count, x+width-io.TextWidth(count), y, io.TextWidth(count), screen.AlignRight, theme.Color(screen.RoleText), 0)
+		y++
+	}
+
+	y++
+	s.screen.RenderText("Learned by month", x, y, theme.Color(screen.RoleTitle), 0)
+	y++
+	for i := len(stats.LearnedByMonth) - 1; i >= 0; i-- { // Most recent first
+		month := stats.LearnedByMonth[i]
+		s.screen.RenderText(month.Month, x, y, theme.Color(screen.RoleMuted), 0)
+		s.screen.RenderAlignedTextInColumn(fmt.Sprintf("%d", month.Words), x+io.TextWidth(month.Month), y, width-io.TextWidth(month.Month), screen.AlignRight, theme.Color(screen.RoleText), 0)
+		y++
+	}
+}
+
+// heatmapLevel ...
+// Gets the heatmap level for an amount of study.
+func heatmapLevel(count int) int {
+	switch {
+	case count <= 0:
+		return 0
+	case count == 1:
+		return 1
+	case count == 2:
+		return 2
+	case count <= 4:
+		return 3
+	default:
+		return 4
+	}
+}
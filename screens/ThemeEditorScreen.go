@@ -0,0 +1,295 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// Theme editor layout (within the content area)
+const (
+	themeEditorRolesX   = 1
+	themeEditorPreviewX = 19
+	themeEditorGridX    = 43
+	themeEditorTop      = 2
+	previewWidth        = 22
+	previewHeight       = 9
+)
+
+// defaultThemeName ...
+// The name offered when saving a theme that was edited from a built-in theme.
+const defaultThemeName = "custom"
+
+// ThemeEditorScreen ...
+// Edits the colors of a theme. The user picks a role, then picks its color from a grid while a sample
+// word card previews the result.
+type ThemeEditorScreen struct {
+	screen        *screen.Screen
+	configuration *configuration.AppConfig
+	theme         *screen.Theme // The theme being edited (a copy of the theme it was opened with)
+	originalName  string        // Name of the theme the editor was opened with
+	roleIndex     int           // Index of the selected role
+	isNaming      bool          // Whether the user is entering a name to save the theme as
+	name          string        // Name the theme will be saved as
+	message       string        // Message about the last save
+	messageRole   screen.Role   // Role used to color the message
+}
+
+// NewThemeEditorScreen ...
+// Instantiates a new theme editor screen.
+func NewThemeEditorScreen(config *configuration.AppConfig, viewport *screen.Viewport, theme *screen.Theme) *ThemeEditorScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
+	screen := screen.NewScreen(viewport, screenStyle)
+	return &ThemeEditorScreen{screen: screen, configuration: config}
+}
+
+// Edit ...
+// Starts editing a copy of a theme.
+func (s *ThemeEditorScreen) Edit(theme *screen.Theme) {
+	s.theme = theme.Copy()
+	s.originalName = theme.Name
+	s.roleIndex = 0
+	s.isNaming = false
+	s.message = ""
+}
+
+// Render ...
+// Renders the theme editor screen.
+func (s *ThemeEditorScreen) Render() {
+	s.screen.Clear()
+	theme := s.screen.GetTheme()
+	if s.theme == nil {
+		s.Edit(theme)
+	}
+
+	s.screen.RenderText(fmt.Sprintf("Theme Editor (%s)", s.originalName), 1, 0, theme.Color(screen.RoleTitle), 0)
+
+	// Render roles (with their colors)
+	for i, role := range screen.Roles {
+		marker := " "
+		bgColor := 0
+		if i == s.roleIndex {
+			marker = "›"
+			bgColor = theme.Color(screen.RoleHighlight)
+		}
+		str := fmt.Sprintf("%s %-10s %3d", marker, role, s.theme.Color(role))
+		s.screen.RenderText(str, themeEditorRolesX, themeEditorTop+i, theme.Color(screen.RoleText), bgColor)
+	}
+
+	s.renderPreview(themeEditorPreviewX, themeEditorTop)
+
+	// Render the page of the color grid containing the selected color
+	role := s.selectedRole()
+	numColors := s.numColors()
+	rows := s.gridRows()
+	columns := (s.screen.GetContentWidth() - themeEditorGridX) / colorGridColumnWidth
+	if columns < 1 {
+		columns = 1
+	}
+	colorsPerPage := rows * columns
+	first := (s.cursor() / colorsPerPage) * colorsPerPage
+	last := first + colorsPerPage
+	if last > numColors {
+		last = numColors
+	}
+	renderColorGrid(s.screen, themeEditorGridX, themeEditorTop, rows, first, last, role == screen.RoleHighlight, s.theme.Color(screen.RoleText), s.cursor())
+
+	// Render save message and the name being entered
+	bottom := s.screen.GetContentHeight() - 1
+	if s.message != "" {
+		s.screen.RenderText(s.message, 1, bottom-1, theme.Color(s.messageRole), 0)
+	}
+	if s.isNaming {
+		s.screen.RenderText("Save as: "+s.name+"_", 1, bottom, theme.Color(screen.RoleText), 0)
+	}
+}
+
+// renderPreview ...
+// Renders a sample word card in the theme being edited.
+func (s *ThemeEditorScreen) renderPreview(x int, y int) {
+	t := s.theme
+	if !s.screen.RenderBox(x, y, previewWidth, previewHeight, t.Color(screen.RoleBorder), 0) {
+		return
+	}
+	x++
+	y++
+	s.screen.RenderText("hello", x+1, y, t.Color(screen.RoleTitle), 0)
+	s.screen.RenderText("fr", x+1, y+1, t.Accent("fr"), 0)
+	s.screen.RenderText("bonjour", x+4, y+1, t.Color(screen.RoleText), 0)
+	s.screen.RenderText("el", x+1, y+2, t.Accent("el"), 0)
+	s.screen.RenderText("χαίρετε", x+4, y+2, t.Color(screen.RoleText), 0)
+	s.screen.RenderText("chaírete", x+4, y+3, t.Color(screen.RoleMuted), 0)
+	s.screen.RenderText("✓ learned", x+1, y+4, t.Color(screen.RoleSuccess), 0)
+	s.screen.RenderText("✗ not found", x+1, y+5, t.Color(screen.RoleError), 0)
+	s.screen.RenderAlignedTextInColumn(" selected", x, y+6, previewWidth-2, screen.AlignLeft, t.Color(screen.RoleText), t.Color(screen.RoleHighlight))
+}
+
+// SelectNextRole ...
+// Selects the next role to edit.
+func (s *ThemeEditorScreen) SelectNextRole() {
+	if s.roleIndex < len(screen.Roles)-1 {
+		s.roleIndex++
+	}
+}
+
+// SelectPreviousRole ...
+// Selects the previous role to edit.
+func (s *ThemeEditorScreen) SelectPreviousRole() {
+	if s.roleIndex > 0 {
+		s.roleIndex--
+	}
+}
+
+// MoveCursor ...
+// Moves the grid cursor by a number of columns and rows, setting the selected role's color to the color
+// under the cursor.
+func (s *ThemeEditorScreen) MoveCursor(columns int, rows int) {
+	color := s.cursor() + rows + columns*s.gridRows()
+	if color < 0 {
+		color = 0
+	}
+	if color >= s.numColors() {
+		color = s.numColors() - 1
+	}
+	s.theme.Colors[s.selectedRole()] = color
+}
+
+// StartNaming ...
+// Starts entering the name to save the theme as.
+func (s *ThemeEditorScreen) StartNaming() {
+	s.isNaming = true
+	s.name = s.originalName
+	if screen.GetBuiltInTheme(s.name) != nil {
+		s.name = defaultThemeName
+	}
+}
+
+// IsNaming ...
+// Determines whether the user is entering a name to save the theme as.
+func (s *ThemeEditorScreen) IsNaming() bool {
+	return s.isNaming
+}
+
+// TypeNameCharacter ...
+// Adds a character to the name being entered.
+func (s *ThemeEditorScreen) TypeNameCharacter(ch rune) {
+	s.name += string(ch)
+}
+
+// DeleteNameCharacter ...
+// Removes the last character from the name being entered.
+func (s *ThemeEditorScreen) DeleteNameCharacter() {
+	runes := []rune(s.name)
+	if len(runes) > 0 {
+		s.name = string(runes[:len(runes)-1])
+	}
+}
+
+// CancelNaming ...
+// Stops entering a name without saving.
+func (s *ThemeEditorScreen) CancelNaming() {
+	s.isNaming = false
+}
+
+// Save ...
+// Stores the edited theme in configuration under the entered name and selects it.
+// Returns the saved theme, or an error if the name cannot be used.
+func (s *ThemeEditorScreen) Save() (*screen.Theme, error) {
+	name := strings.TrimSpace(s.name)
+	if name == "" || screen.GetBuiltInTheme(name) != nil {
+		s.message = "Enter a name that is not used by a built-in theme."
+		s.messageRole = screen.RoleError
+		return nil, fmt.Errorf("invalid theme name %q", name)
+	}
+
+	// Store every role so the saved theme does not change if its base theme does
+	themeConfig := configuration.ThemeConfig{Base: s.baseName(), Colors: map[string]int{}, Accents: map[string]int{}}
+	for _, role := range screen.Roles {
+		themeConfig.Colors[string(role)] = s.theme.Color(role)
+	}
+	for languageCode, color := range s.theme.Accents {
+		themeConfig.Accents[languageCode] = color
+	}
+	s.configuration.SaveTheme(name, themeConfig)
+
+	s.theme.Name = name
+	s.originalName = name
+	s.isNaming = false
+	s.message = fmt.Sprintf("Saved theme %s.", name)
+	s.messageRole = screen.RoleSuccess
+
+	return s.theme.Copy(), nil
+}
+
+// baseName ...
+// Gets the name of the built-in theme that the edited theme is based on.
+func (s *ThemeEditorScreen) baseName() string {
+	if screen.GetBuiltInTheme(s.originalName) != nil {
+		return s.originalName
+	}
+	if themeConfig, ok := s.configuration.Themes[s.originalName]; ok && themeConfig.Base != "" {
+		return themeConfig.Base
+	}
+
+	return screen.DarkTheme.Name
+}
+
+// selectedRole ...
+// Gets the role being edited.
+func (s *ThemeEditorScreen) selectedRole() screen.Role {
+	return screen.Roles[s.roleIndex]
+}
+
+// cursor ...
+// Gets the color under the grid cursor (the selected role's color, limited to the colors in the grid).
+func (s *ThemeEditorScreen) cursor() int {
+	color := s.theme.Color(s.selectedRole())
+	if color >= s.numColors() {
+		color = s.numColors() - 1
+	}
+
+	return color
+}
+
+// numColors ...
+// Gets the number of colors in the grid. Monochrome terminals show the basic colors, which are rendered
+// as attributes.
+func (s *ThemeEditorScreen) numColors() int {
+	numColors := io.GetColorMode().NumColors()
+	if numColors == 0 {
+		numColors = 16
+	}
+
+	return numColors
+}
+
+// gridRows ...
+// Gets the number of rows in each column of the color grid.
+func (s *ThemeEditorScreen) gridRows() int {
+	rows := s.screen.GetContentHeight() - themeEditorTop - 2
+	if rows < 1 {
+		rows = 1
+	}
+
+	return rows
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"testing"
+
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+func TestThemeEditorMoveCursorStaysInGrid(t *testing.T) {
+	editor := NewThemeEditorScreen(testConfiguration(), screen.NewViewport(0, 0, 80, 17), screen.DarkTheme)
+	editor.Edit(screen.DarkTheme)
+
+	editor.MoveCursor(0, -10)
+	if color := editor.theme.Color(screen.RoleBorder); color != 0 {
+		t.Errorf("Expected border color 0, got %d", color)
+	}
+	editor.MoveCursor(1, 2)
+	if color := editor.theme.Color(screen.RoleBorder); color != editor.gridRows()+2 {
+		t.Errorf("Expected border color %d, got %d", editor.gridRows()+2, color)
+	}
+	editor.MoveCursor(100, 0)
+	if color := editor.theme.Color(screen.RoleBorder); color != 255 {
+		t.Errorf("Expected border color 255, got %d", color)
+	}
+	if color := screen.DarkTheme.Color(screen.RoleBorder); color != 5 {
+		t.Errorf("Expected built-in theme to be unchanged, got border color %d", color)
+	}
+}
+
+func TestThemeEditorSaveRejectsBuiltInNames(t *testing.T) {
+	config := testConfiguration()
+	editor := NewThemeEditorScreen(config, screen.NewViewport(0, 0, 80, 17), screen.DarkTheme)
+	editor.Edit(screen.DarkTheme)
+	editor.StartNaming()
+	for range "custom" {
+		editor.DeleteNameCharacter()
+	}
+	for _, ch := range "light" {
+		editor.TypeNameCharacter(ch)
+	}
+
+	if _, err := editor.Save(); err == nil {
+		t.Error("Expected an error saving a theme named after a built-in theme")
+	}
+	if len(config.Themes) != 0 {
+		t.Errorf("Expected no themes to be saved, got %v", config.Themes)
+	}
+}
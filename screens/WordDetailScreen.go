@@ -0,0 +1,322 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stuartthompson/dailyvocab/app"
+	"github.com/stuartthompson/dailyvocab/configuration"
+	"github.com/stuartthompson/dailyvocab/io"
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+// Layout of the translations table (on screens wide enough for it)
+const (
+	languageColumnWidth    = 14
+	translationColumnWidth = 24
+	minPronunciationWidth  = 8 // Narrower pronunciation columns are moved under translations
+)
+
+// WordDetailScreen ...
+type WordDetailScreen struct {
+	screen        *screen.Screen
+	configuration *configuration.AppConfig // Application configuration
+	vocabulary    *app.Vocabulary          // The word list containing the word
+	wordID        int                      // The id of the word being displayed
+}
+
+// NewWordDetailScreen ...
+// Instantiates a new word detail screen.
+func NewWordDetailScreen(config *configuration.AppConfig, vocabulary *app.Vocabulary, viewport *screen.Viewport, theme *screen.Theme) *WordDetailScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
+	screen := screen.NewScreen(viewport, screenStyle)
+	return &WordDetailScreen{screen: screen, configuration: config, vocabulary: vocabulary}
+}
+
+// SetWord ...
+// Sets the word to display.
+func (s *WordDetailScreen) SetWord(id int) {
+	s.wordID = id
+}
+
+// GetWordID ...
+// Gets the id of the word being displayed.
+func (s *WordDetailScreen) GetWordID() int {
+	return s.wordID
+}
+
+// Render ...
+// Renders the word detail screen.
+func (s *WordDetailScreen) Render() {
+	s.screen.Clear()
+	theme := s.screen.GetTheme()
+
+	word := s.vocabulary.GetWord(s.wordID)
+	if word == nil {
+		s.screen.RenderText("Word not found", 1, 1, theme.Color(screen.RoleError), 0)
+		return
+	}
+	pair := s.configuration.StudyPair()
+
+	s.screen.RenderText(knownWord(word, pair), 1, 1, theme.Color(screen.RoleTitle), 0)
+	s.screen.RenderAlignedTextInColumn(wordInfo(word), 1, 1, s.screen.GetContentWidth()-2, screen.AlignRight, theme.Color(screen.RoleMuted), 0)
+
+	// Render translations into the known and learning languages, then any others
+	s.screen.RenderText("Translations", 1, 3, theme.Color(screen.RoleTitle), 0)
+	under := s.configuration.RomanizationUnder
+	y := renderTranslations(s.screen, pairTranslations(word, pair), 4, under)
+	var others []app.LocalizedWord
+	for _, translation := range word.Translations {
+		if !pair.IsKnown(translation.LanguageCode) && !pair.IsLearning(translation.LanguageCode) {
+			others = append(others, translation)
+		}
+	}
+	if len(others) > 0 {
+		y++
+		s.screen.RenderText("Other languages", 1, y, theme.Color(screen.RoleTitle), 0)
+		y = renderTranslations(s.screen, others, y+1, under)
+	}
+
+	// Render the grammar of the translations that have any
+	var grammatical []app.LocalizedWord
+	for _, translation := range pairTranslations(word, pair) {
+		if translation.HasGrammar() {
+			grammatical = append(grammatical, translation)
+		}
+	}
+	if len(grammatical) > 0 {
+		y++
+		s.screen.RenderText("Grammar", 1, y, theme.Color(screen.RoleTitle), 0)
+		y = renderGrammar(s.screen, grammatical, y+1)
+	}
+
+	// Render example sentences
+	if translations := pairTranslations(word, pair); hasExamples(translations) {
+		y++
+		s.screen.RenderText("Examples", 1, y, theme.Color(screen.RoleTitle), 0)
+		y = renderExamples(s.screen, translations, y+1, 0)
+	}
+
+	// Render usage
+	y++
+	s.screen.RenderText("Usage", 1, y, theme.Color(screen.RoleTitle), 0)
+	y++
+	for _, usage := range word.Usage {
+		if usage.Meaning == "" {
+			continue
+		}
+		y += s.screen.RenderParagraph(usage.Type+": "+usage.Meaning, 1, y, s.screen.GetContentWidth()-2, screen.AlignLeft, theme.Color(screen.RoleText), 0)
+	}
+}
+
+// wordInfo ...
+// Describes a word's level, tags and frequency rank (e.g. "A1 · #greetings · rank 12").
+func wordInfo(word *app.Word) string {
+	var info []string
+	if word.Level != "" {
+		info = append(info, word.Level)
+	}
+	if len(word.Tags) > 0 {
+		info = append(info, "#"+strings.Join(word.Tags, " #"))
+	}
+	if word.Frequency > 0 {
+		info = append(info, fmt.Sprintf("rank %d", word.Frequency))
+	}
+
+	return strings.Join(info, " · ")
+}
+
+// knownWord ...
+// Gets a word in the known language (or an empty string if it is not translated into it).
+func knownWord(word *app.Word, pair app.StudyPair) string {
+	if known := word.KnownTranslation(pair); known != nil {
+		return known.Native
+	}
+
+	return ""
+}
+
+// pairTranslations ...
+// Gets a word's translation into the known language (if it has one) followed by its translations into the
+// languages being learned.
+func pairTranslations(word *app.Word, pair app.StudyPair) []app.LocalizedWord {
+	var translations []app.LocalizedWord
+	if known := word.KnownTranslation(pair); known != nil {
+		translations = append(translations, *known)
+	}
+
+	return append(translations, word.StudyTranslations(pair)...)
+}
+
+// languageWidth ...
+// Gets the width of the column of language names, which is narrowed on small screens.
+func languageWidth(s *screen.Screen) int {
+	if width := s.GetContentWidth() * 2 / 5; width < languageColumnWidth {
+		return width
+	}
+
+	return languageColumnWidth
+}
+
+// renderTranslations ...
+// Renders a table of translations (labelled with the names of their languages), one per row starting at y.
+// Translations into right-to-left languages are aligned to the right of their column. Pronunciations (anglicized
+// forms and IPA) are shown beside translations, or on the row under them if under is set or the screen is too
+// narrow to fit them beside. Returns the row following the table.
+func renderTranslations(s *screen.Screen, translations []app.LocalizedWord, y int, under bool) int {
+	theme := s.GetTheme()
+	nativeX := 1 + languageWidth(s)
+	nativeWidth := s.GetContentWidth() - nativeX - 1
+	if nativeWidth > translationColumnWidth {
+		nativeWidth = translationColumnWidth
+	}
+	pronunciationX := nativeX + nativeWidth + 2
+	pronunciationWidth := s.GetContentWidth() - pronunciationX - 1
+	if pronunciationWidth < minPronunciationWidth {
+		under = true
+	}
+	for _, translation := range translations {
+		alignment := textAlignment(translation.LanguageCode, translation.Native)
+		s.RenderAlignedTextInColumn(app.LanguageName(translation.LanguageCode), 1, y, nativeX-1, screen.AlignLeft, theme.Accent(translation.LanguageCode), 0)
+		s.RenderAlignedTextInColumn(translation.Native, nativeX, y, nativeWidth, alignment, theme.Color(screen.RoleText), 0)
+		pronunciation := translation.Pronunciation()
+		switch {
+		case pronunciation == "":
+		case under:
+			y++
+			s.RenderAlignedTextInColumn(pronunciation, nativeX, y, nativeWidth, alignment, theme.Color(screen.RoleMuted), 0)
+		default:
+			s.RenderAlignedTextInColumn(pronunciation, pronunciationX, y, pronunciationWidth, screen.AlignLeft, theme.Color(screen.RoleMuted), 0)
+		}
+		y++
+	}
+
+	return y
+}
+
+// textAlignment ...
+// Gets the alignment of text in a language within a column: to the right for right-to-left languages.
+func textAlignment(languageCode string, text string) screen.Alignment {
+	language, ok := app.LookupLanguage(languageCode)
+	switch {
+	case !ok:
+		return screen.NaturalAlignment(text)
+	case language.Direction == app.RightToLeft:
+		return screen.AlignRight
+	}
+
+	return screen.AlignLeft
+}
+
+// hasExamples ...
+// Determines whether any of a word's translations have example sentences.
+func hasExamples(translations []app.LocalizedWord) bool {
+	for _, translation := range translations {
+		if len(translation.Examples) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderExamples ...
+// Renders the example sentences of translations (labelled with the names of their languages), with the word
+// highlighted and the sentence in the known language under it. At most limit examples are rendered for each
+// translation (or all of them if limit is zero). Returns the row following the examples.
+func renderExamples(s *screen.Screen, translations []app.LocalizedWord, y int, limit int) int {
+	theme := s.GetTheme()
+	x := 1 + languageWidth(s)
+	width := s.GetContentWidth() - x - 1
+	for _, translation := range translations {
+		examples := translation.Examples
+		if limit > 0 && len(examples) > limit {
+			examples = examples[:limit]
+		}
+		for _, example := range examples {
+			s.RenderAlignedTextInColumn(app.LanguageName(translation.LanguageCode), 1, y, x-1, screen.AlignLeft, theme.Accent(translation.LanguageCode), 0)
+			renderExample(s, example, translation.LanguageCode, x, y, width)
+			y++
+			if example.Translation != "" {
+				s.RenderAlignedTextInColumn(example.Translation, x, y, width, screen.AlignLeft, theme.Color(screen.RoleMuted), 0)
+				y++
+			}
+		}
+	}
+
+	return y
+}
+
+// renderExample ...
+// Renders an example sentence in a column, with the word it uses highlighted. Sentences that are too wide for the
+// column are truncated without highlighting.
+func renderExample(s *screen.Screen, example app.Example, languageCode string, x int, y int, width int) {
+	theme := s.GetTheme()
+	text := example.Text()
+	alignment := textAlignment(languageCode, text)
+	before, word, after, ok := example.Parts()
+	if !ok || io.TextWidth(text) > width {
+		s.RenderAlignedTextInColumn(text, x, y, width, alignment, theme.Color(screen.RoleText), 0)
+		return
+	}
+
+	// Render the parts of the sentence from left to right (the end of the sentence first, if it is written
+	// from right to left)
+	parts := []struct {
+		text    string
+		fgColor int
+		bgColor int
+	}{
+		{before, theme.Color(screen.RoleText), 0},
+		{word, theme.Accent(languageCode), theme.Color(screen.RoleHighlight)},
+		{after, theme.Color(screen.RoleText), 0},
+	}
+	if alignment == screen.AlignRight {
+		parts[0], parts[2] = parts[2], parts[0]
+		x += width - io.TextWidth(text)
+	}
+	for _, part := range parts {
+		s.RenderText(part.text, x, y, part.fgColor, part.bgColor)
+		x += io.TextWidth(part.text)
+	}
+}
+
+// renderGrammar ...
+// Renders the article, gender and plural of translations (labelled with the names of their languages), each
+// followed by its inflected forms. Returns the row following the table.
+func renderGrammar(s *screen.Screen, translations []app.LocalizedWord, y int) int {
+	theme := s.GetTheme()
+	x := 1 + languageWidth(s)
+	for _, translation := range translations {
+		s.RenderAlignedTextInColumn(app.LanguageName(translation.LanguageCode), 1, y, x-1, screen.AlignLeft, theme.Accent(translation.LanguageCode), 0)
+		s.RenderText(translation.GrammarSummary(), x, y, theme.Color(screen.RoleText), 0)
+		y++
+
+		var forms []string
+		for _, name := range translation.FormNames() {
+			forms = append(forms, name+": "+translation.Forms[name])
+		}
+		if len(forms) > 0 {
+			y += s.RenderParagraph(strings.Join(forms, ", "), x, y, s.GetContentWidth()-x-1, screen.AlignLeft, theme.Color(screen.RoleMuted), 0)
+		}
+	}
+
+	return y
+}
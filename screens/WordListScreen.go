@@ -19,87 +19,185 @@ package screens
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/stuartthompson/dailyvocab/app"
 	"github.com/stuartthompson/dailyvocab/configuration"
 	"github.com/stuartthompson/dailyvocab/io/screen"
 )
 
-// checkmarkColor ...
-// Color used for the checkmark displayed next to viewed words.
-const checkmarkColor = 3 // Green
-
-// screenBorderColor ...
-// Color used for the word list screen border.
-const screenBorderColor = 5 // Blue
-
 // WordListScreen ...
 type WordListScreen struct {
 	screen        *screen.Screen
 	configuration *configuration.AppConfig // Application configuration
 	viewedWords   map[int]string           // Map of viewed words by id
 	vocabulary    *app.Vocabulary          // The word list to render
+	selectedIndex int                      // Index of the selected word
+	filter        app.WordFilter           // Filter the listed words must match
+	searchText    string                   // The filter being entered
+	searching     bool                     // Whether a filter is being entered
 }
 
 // NewWordListScreen ...
 // Instantiates a new word list screen.
-func NewWordListScreen(config *configuration.AppConfig, vocabulary *app.Vocabulary, viewport *screen.Viewport) *WordListScreen {
-	screenStyle := &screen.Style{ShowBorder: true, BorderColor: screenBorderColor}
+func NewWordListScreen(config *configuration.AppConfig, vocabulary *app.Vocabulary, viewport *screen.Viewport, theme *screen.Theme) *WordListScreen {
+	screenStyle := &screen.Style{ShowBorder: true, Theme: theme}
 	screen := screen.NewScreen(viewport, screenStyle)
 
 	// Create new word list screen
-	wordListScreen := &WordListScreen{screen: screen, configuration: config, vocabulary: vocabulary}
-
-	// Build viewed words map
-	wordListScreen.viewedWords = buildViewedWordsMap(config.ViewedWords)
-
-	// Return new word list screen
-	return wordListScreen
+	return &WordListScreen{screen: screen, configuration: config, vocabulary: vocabulary}
 }
 
 // Render ...
 // Renders the about screen.
 func (s *WordListScreen) Render() {
 	s.screen.Clear()
+	theme := s.screen.GetTheme()
+
+	// Build viewed words map (words may have been marked viewed since the last render)
+	s.viewedWords = buildViewedWordsMap(s.configuration.ViewedWords)
 
-	s.screen.RenderText("Word List", 1, 0, 255, 0)
+	s.screen.RenderText("Word List", 1, 0, theme.Color(screen.RoleTitle), 0)
 
-	// Render header
-	totalWords := len(s.vocabulary.Words)
+	// Render the filter (or the one being entered)
+	if s.searching {
+		s.screen.RenderAlignedTextInColumn("Filter: "+s.searchText+"_", 12, 0, s.screen.GetContentWidth()-13, screen.AlignRight, theme.Color(screen.RoleText), 0)
+	} else if !s.filter.IsEmpty() {
+		s.screen.RenderAlignedTextInColumn("Filter: "+s.filter.String(), 12, 0, s.screen.GetContentWidth()-13, screen.AlignRight, theme.Color(screen.RoleMuted), 0)
+	}
+
+	// Render header (words without a translation into a language being learned are not listed)
+	words := s.words()
+	totalWords := len(words)
+	if s.selectedIndex >= totalWords && totalWords > 0 {
+		s.selectedIndex = totalWords - 1
+	}
 	viewedWords := len(s.configuration.ViewedWords)
-	// Determine how many words will be displayed
-	wordsPerPage := s.screen.GetHeight() - 5
-	pageNum := 1
-	startIndex := (wordsPerPage * (pageNum - 1)) + 1
+	// Determine which page of words will be displayed (the page containing the selected word)
+	wordsPerPage := s.screen.GetContentHeight() - 4
+	if wordsPerPage < 1 {
+		wordsPerPage = 1
+	}
+	startIndex := (s.selectedIndex / wordsPerPage) * wordsPerPage
 	endIndex := startIndex + wordsPerPage
 	if endIndex > totalWords {
 		endIndex = totalWords
 	}
 	// Render header text
-	headerText := fmt.Sprintf("Showing %d - %d of %d total words. Viewed %d.", startIndex, endIndex, totalWords, viewedWords)
-	s.screen.RenderText(headerText, 1, 2, 255, 0)
+	headerText := fmt.Sprintf("Showing %d - %d of %d total words. Viewed %d.", startIndex+1, endIndex, totalWords, viewedWords)
+	s.screen.RenderText(headerText, 1, 2, theme.Color(screen.RoleText), 0)
 
 	// Render word list
-	for i := 0; i < len(s.vocabulary.Words); i++ {
+	for i := startIndex; i < endIndex; i++ {
 		// Calculate y-coordinate at which to render this line
-		y := 4 + i
-
-		w := s.vocabulary.Words[i]
-		// Get the word in the default language
-		word := s.vocabulary.GetWordInLanguage(w.ID, s.configuration.DefaultLanguage)
-		// Get total number of languages
-		numLangs := len(s.vocabulary.Words[i].Translations)
+		y := 4 + i - startIndex
+
+		w := words[i]
+		// Get the word in the known language, and its forms in the languages being learned
+		word := knownWord(w, s.configuration.StudyPair())
+		var forms []string
+		for _, translation := range w.StudyTranslations(s.configuration.StudyPair()) {
+			forms = append(forms, translation.Native)
+		}
 		// Render "viewed" checkmark (if word is marked viewed)
 		if s.viewedWords[w.ID] != "" {
-			s.screen.RenderText("✓", 1, y, checkmarkColor, 0)
+			s.screen.RenderText("✓", 1, y, theme.Color(screen.RoleSuccess), 0)
+		}
+		// Highlight the selected word
+		bgColor := 0
+		if i == s.selectedIndex {
+			bgColor = theme.Color(screen.RoleHighlight)
 		}
 		// Render main list item text
-		str := fmt.Sprintf("[%d] %s (in %d languages)", w.ID, word, numLangs)
-		s.screen.RenderText(str, 3, y, 255, 0)
+		str := fmt.Sprintf("[%d] %s — %s", w.ID, word, strings.Join(forms, ", "))
+		s.screen.RenderAlignedTextInColumn(str, 3, y, s.screen.GetContentWidth()-4, screen.AlignLeft, theme.Color(screen.RoleText), bgColor)
+
+	}
+}
 
+// SelectNext ...
+// Moves the selection to the next word in the list.
+func (s *WordListScreen) SelectNext() {
+	if s.selectedIndex < len(s.words())-1 {
+		s.selectedIndex++
 	}
 }
 
+// SelectPrevious ...
+// Moves the selection to the previous word in the list.
+func (s *WordListScreen) SelectPrevious() {
+	if s.selectedIndex > 0 {
+		s.selectedIndex--
+	}
+}
+
+// GetSelectedWordID ...
+// Gets the id of the selected word (or zero if the list is empty).
+func (s *WordListScreen) GetSelectedWordID() int {
+	words := s.words()
+	if s.selectedIndex >= len(words) {
+		return 0
+	}
+
+	return words[s.selectedIndex].ID
+}
+
+// GetFilter ...
+// Gets the filter the listed words match.
+func (s *WordListScreen) GetFilter() app.WordFilter {
+	return s.filter
+}
+
+// StartSearch ...
+// Starts entering a filter (see app.ParseWordFilter), beginning with the current one.
+func (s *WordListScreen) StartSearch() {
+	s.searching = true
+	s.searchText = s.filter.String()
+}
+
+// IsSearching ...
+// Determines whether a filter is being entered.
+func (s *WordListScreen) IsSearching() bool {
+	return s.searching
+}
+
+// TypeSearchCharacter ...
+// Adds a character to the filter being entered.
+func (s *WordListScreen) TypeSearchCharacter(ch rune) {
+	s.searchText += string(ch)
+}
+
+// DeleteSearchCharacter ...
+// Removes the last character from the filter being entered.
+func (s *WordListScreen) DeleteSearchCharacter() {
+	runes := []rune(s.searchText)
+	if len(runes) > 0 {
+		s.searchText = string(runes[:len(runes)-1])
+	}
+}
+
+// ApplySearch ...
+// Filters the list with the filter that was entered, selecting the first matching word.
+func (s *WordListScreen) ApplySearch() {
+	s.filter = app.ParseWordFilter(s.searchText)
+	s.searching = false
+	s.selectedIndex = 0
+}
+
+// ClearSearch ...
+// Stops entering a filter and lists all words again.
+func (s *WordListScreen) ClearSearch() {
+	s.filter = app.WordFilter{}
+	s.searching = false
+	s.selectedIndex = 0
+}
+
+// words ...
+// Gets the listed words: those that can be studied (translated into a language being learned) and match the filter.
+func (s *WordListScreen) words() []*app.Word {
+	return s.filter.Filter(s.vocabulary.StudyWords(s.configuration.StudyPair()))
+}
+
 // buildViewedWordsMap ...
 // Builds a map that is used to quickly look up words that have been viewed.
 // This is an optimization to speed up checking if a word has been viewed during the render cycle.
@@ -0,0 +1,45 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package screens
+
+import (
+	"testing"
+
+	"github.com/stuartthompson/dailyvocab/io/screen"
+)
+
+func TestWordListScreenListsStudyWords(t *testing.T) {
+	config := testConfiguration()
+	config.StudyLanguages = []string{"el", "fr"}
+	wordListScreen := NewWordListScreen(config, testVocabulary(), screen.NewViewport(0, 0, 80, 17), screen.DarkTheme)
+
+	var ids []int
+	for i := 0; i < 5; i++ {
+		ids = append(ids, wordListScreen.GetSelectedWordID())
+		wordListScreen.SelectNext()
+	}
+	if ids[0] != 1 || ids[1] != 2 || ids[2] != 3 || ids[3] != 3 {
+		t.Errorf("Expected words 1 to 3 to be listed, got %v", ids)
+	}
+
+	config.StudyLanguages = []string{"el"}
+	wordListScreen.Render()
+	if id := wordListScreen.GetSelectedWordID(); id != 3 {
+		t.Errorf("Expected only word 3 to be listed, got %d", id)
+	}
+}
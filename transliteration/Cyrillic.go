@@ -0,0 +1,76 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package transliteration
+
+import "strings"
+
+// russianLetters ...
+// Romanization of Russian letters.
+var russianLetters = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// ukrainianLetters ...
+// Romanization of Ukrainian letters (where it differs from Russian).
+var ukrainianLetters = map[rune]string{
+	'г': "h", 'ґ': "g", 'е': "e", 'є': "ye", 'и': "y", 'і': "i", 'ї': "yi", 'щ': "shch",
+}
+
+// ukrainianMedialLetters ...
+// Romanization of Ukrainian letters that are written differently after the start of a word.
+var ukrainianMedialLetters = map[rune]string{
+	'є': "ie", 'ї': "i", 'й': "i", 'ю': "iu", 'я': "ia",
+}
+
+// romanizeRussian ...
+// Romanizes a Russian word.
+func romanizeRussian(word []rune) (string, bool) {
+	return romanizeCyrillic(word, nil, nil)
+}
+
+// romanizeUkrainian ...
+// Romanizes a Ukrainian word.
+func romanizeUkrainian(word []rune) (string, bool) {
+	return romanizeCyrillic(word, ukrainianLetters, ukrainianMedialLetters)
+}
+
+// romanizeCyrillic ...
+// Romanizes a Cyrillic word using the Russian letters, with some letters replaced (and some others
+// replaced only after the first letter).
+func romanizeCyrillic(word []rune, replaced map[rune]string, medial map[rune]string) (string, bool) {
+	var result strings.Builder
+	for i, letter := range []rune(strings.ToLower(string(word))) {
+		romanized, ok := medial[letter]
+		if !ok || i == 0 {
+			romanized, ok = replaced[letter]
+		}
+		if !ok {
+			romanized, ok = russianLetters[letter]
+		}
+		if !ok {
+			return "", false
+		}
+		result.WriteString(romanized)
+	}
+
+	return result.String(), true
+}
@@ -0,0 +1,247 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package transliteration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/stuartthompson/dailyvocab/app"
+)
+
+// Romanization ...
+// Represents an anglicized form filled in for a translation.
+type Romanization struct {
+	WordID       int
+	LanguageCode string
+	Native       string
+	Anglicized   string
+}
+
+// String ...
+// Describes the romanization (e.g. "word 3: el ευχαριστώ → efcharistó").
+func (r Romanization) String() string {
+	return fmt.Sprintf("word %d: %s %s → %s", r.WordID, r.LanguageCode, r.Native, r.Anglicized)
+}
+
+// fileEdit ...
+// Represents a replacement of a range of bytes in a word list file.
+type fileEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// wordListScanner ...
+// Walks the JSON of a word list, finding the translations that can be romanized and the edits that fill them in.
+type wordListScanner struct {
+	content       []byte
+	decoder       *json.Decoder
+	edits         []fileEdit
+	romanizations []Romanization
+}
+
+// BackfillFile ...
+// Fills in the anglicized form of translations in the content of a word list file (see Backfill). Only the
+// filled-in values are changed, so the layout of the file and any fields the application does not read are
+// kept. Returns the new content and the translations that were filled in.
+func BackfillFile(content []byte) ([]byte, []Romanization, error) {
+	scanner := &wordListScanner{content: content, decoder: json.NewDecoder(bytes.NewReader(content))}
+	scanner.decoder.UseNumber()
+	if err := scanner.scanFile(); err != nil {
+		return nil, nil, err
+	}
+
+	// Apply the edits from the end of the file, so that earlier offsets stay valid
+	sort.Slice(scanner.edits, func(i, j int) bool { return scanner.edits[i].start > scanner.edits[j].start })
+	result := append([]byte{}, content...)
+	for _, edit := range scanner.edits {
+		result = append(result[:edit.start], append([]byte(edit.text), result[edit.end:]...)...)
+	}
+
+	return result, scanner.romanizations, nil
+}
+
+// scanFile ...
+// Scans a word list file: either an array of words, or an object with a header and the array of words.
+func (s *wordListScanner) scanFile() error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == json.Delim('[') {
+		return s.scanWords()
+	}
+	if token != json.Delim('{') {
+		return errors.New("word list is not in the expected format (expected [ or {)")
+	}
+	for s.decoder.More() {
+		key, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if key == "words" {
+			if err := s.expectDelim('['); err != nil {
+				return err
+			}
+			if err := s.scanWords(); err != nil {
+				return err
+			}
+			continue
+		}
+		var ignored json.RawMessage
+		if err := s.decoder.Decode(&ignored); err != nil {
+			return err
+		}
+	}
+
+	return s.expectDelim('}')
+}
+
+// scanWords ...
+// Scans the words in an array (after its opening bracket).
+func (s *wordListScanner) scanWords() error {
+	for s.decoder.More() {
+		if err := s.scanWord(); err != nil {
+			return err
+		}
+	}
+
+	return s.expectDelim(']')
+}
+
+// scanWord ...
+// Scans a word, and the translations in it.
+func (s *wordListScanner) scanWord() error {
+	if err := s.expectDelim('{'); err != nil {
+		return err
+	}
+	first := len(s.romanizations)
+	id := 0
+	for s.decoder.More() {
+		key, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id":
+			if err := s.decoder.Decode(&id); err != nil {
+				return err
+			}
+		case "translations":
+			if err := s.expectDelim('['); err != nil {
+				return err
+			}
+			for s.decoder.More() {
+				if err := s.scanTranslation(); err != nil {
+					return err
+				}
+			}
+			if err := s.expectDelim(']'); err != nil {
+				return err
+			}
+		default:
+			var ignored json.RawMessage
+			if err := s.decoder.Decode(&ignored); err != nil {
+				return err
+			}
+		}
+	}
+	for i := first; i < len(s.romanizations); i++ {
+		s.romanizations[i].WordID = id
+	}
+
+	return s.expectDelim('}')
+}
+
+// scanTranslation ...
+// Scans a translation, adding an edit that fills in its anglicized form if it can be romanized.
+func (s *wordListScanner) scanTranslation() error {
+	if err := s.expectDelim('{'); err != nil {
+		return err
+	}
+	var translation app.LocalizedWord
+	anglicizedStart := -1
+	anglicizedEnd := -1
+	for s.decoder.More() {
+		key, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+		start := int(s.decoder.InputOffset())
+		var value interface{}
+		if err := s.decoder.Decode(&value); err != nil {
+			return err
+		}
+		text, _ := value.(string)
+		switch key {
+		case "languageCode":
+			translation.LanguageCode = text
+		case "native":
+			translation.Native = text
+		case "anglicized":
+			translation.Anglicized = text
+			anglicizedStart, anglicizedEnd = start, int(s.decoder.InputOffset())
+		}
+	}
+	if err := s.expectDelim('}'); err != nil {
+		return err
+	}
+	end := int(s.decoder.InputOffset()) - 1
+	for end > 0 && isJSONSpace(s.content[end-1]) {
+		end--
+	}
+
+	if !backfillTranslation(&translation) {
+		return nil
+	}
+	value, _ := json.Marshal(translation.Anglicized)
+	if anglicizedStart >= 0 {
+		// Replace the empty value (from after its key)
+		s.edits = append(s.edits, fileEdit{start: anglicizedStart, end: anglicizedEnd, text: ": " + string(value)})
+	} else {
+		// Add the field after the last one, before any space preceding the closing brace
+		s.edits = append(s.edits, fileEdit{start: end, end: end, text: `, "anglicized": ` + string(value)})
+	}
+	s.romanizations = append(s.romanizations, Romanization{LanguageCode: translation.LanguageCode, Native: translation.Native, Anglicized: translation.Anglicized})
+
+	return nil
+}
+
+// expectDelim ...
+// Reads a delimiter, failing if the next token is anything else.
+func (s *wordListScanner) expectDelim(delim json.Delim) error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return errors.New("word list is not in the expected format (expected " + delim.String() + ")")
+	}
+
+	return nil
+}
+
+// isJSONSpace ...
+// Determines whether a byte is whitespace between JSON tokens.
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package transliteration
+
+import (
+	"strings"
+	"unicode"
+)
+
+// greekLetters ...
+// Romanization of single Greek letters (ELOT 743).
+var greekLetters = map[rune]string{
+	'α': "a", 'ά': "á", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'έ': "é", 'ζ': "z",
+	'η': "i", 'ή': "í", 'θ': "th", 'ι': "i", 'ί': "í", 'ϊ': "ï", 'ΐ': "ḯ", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'ό': "ó", 'π': "p", 'ρ': "r",
+	'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'ύ': "ý", 'ϋ': "ÿ", 'ΰ': "ÿ", 'φ': "f",
+	'χ': "ch", 'ψ': "ps", 'ω': "o", 'ώ': "ó",
+}
+
+// greekVoiceless ...
+// Letters for voiceless sounds, before which "αυ" and "ευ" are romanized as "af" and "ef".
+const greekVoiceless = "θκξπσςτφχψ"
+
+// greekDigraphs ...
+// Romanization of letter pairs, at the start of a word and elsewhere.
+var greekDigraphs = map[string][2]string{
+	"ου": {"ou", "ou"}, "ού": {"oú", "oú"},
+	"μπ": {"b", "mb"}, "ντ": {"d", "nt"},
+	"γγ": {"ng", "ng"}, "γκ": {"g", "nk"}, "γξ": {"nx", "nx"}, "γχ": {"nch", "nch"},
+}
+
+// romanizeGreek ...
+// Romanizes a Greek word.
+func romanizeGreek(word []rune) (string, bool) {
+	letters := []rune(strings.ToLower(string(word)))
+	var result strings.Builder
+	for i := 0; i < len(letters); i++ {
+		if i+1 < len(letters) {
+			position := 1
+			if i == 0 {
+				position = 0
+			}
+			if digraph, ok := greekDigraphs[string(letters[i:i+2])]; ok {
+				result.WriteString(digraph[position])
+				i++
+				continue
+			}
+
+			// "αυ" and "ευ" are pronounced "av" and "ev" (or "af" and "ef" before voiceless sounds)
+			if (letters[i] == 'α' || letters[i] == 'ε') && (letters[i+1] == 'υ' || letters[i+1] == 'ύ') {
+				vowel := greekLetters[letters[i]]
+				if letters[i+1] == 'ύ' {
+					vowel = greekLetters[map[rune]rune{'α': 'ά', 'ε': 'έ'}[letters[i]]]
+				}
+				consonant := "v"
+				if i+2 >= len(letters) || strings.ContainsRune(greekVoiceless, letters[i+2]) {
+					consonant = "f"
+				}
+				result.WriteString(vowel + consonant)
+				i++
+				continue
+			}
+		}
+
+		romanized, ok := greekLetters[letters[i]]
+		if !ok {
+			if unicode.Is(unicode.Mn, letters[i]) {
+				continue
+			}
+			return "", false
+		}
+		result.WriteString(romanized)
+	}
+
+	return result.String(), true
+}
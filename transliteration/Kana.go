@@ -0,0 +1,137 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package transliteration
+
+import "strings"
+
+// kanaSyllables ...
+// Hepburn romanization of hiragana (katakana are converted to hiragana first).
+var kanaSyllables = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+// kanaSmallVowels ...
+// The small vowels that combine with the syllable before them in loanwords (e.g. "fa" and "ti").
+var kanaSmallVowels = map[rune]string{'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o"}
+
+// kanaDigraphConsonants ...
+// The consonant that a syllable keeps when it is followed by a small vowel (e.g. "fu" and small "a" make "fa").
+var kanaDigraphConsonants = map[string]string{
+	"fu": "f", "vu": "v", "tsu": "ts", "te": "t", "de": "d", "to": "t", "do": "d",
+	"shi": "sh", "chi": "ch", "ji": "j", "u": "w", "i": "y",
+}
+
+// kanaGlides ...
+// Romanization of the small "ya", "yu" and "yo" that combine with the syllable before them.
+var kanaGlides = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// romanizeKana ...
+// Romanizes a word written in hiragana or katakana. Returns false if it contains kanji, or small kana with
+// nothing to combine with (e.g. a small "tsu" at the end of the word, or a small "ya" after "ka").
+func romanizeKana(word []rune) (string, bool) {
+	syllables := []string{}
+	double := false
+	for _, kana := range word {
+		// Katakana are offset from the matching hiragana
+		if kana >= 'ァ' && kana <= 'ヶ' {
+			kana -= 0x60
+		}
+
+		switch {
+		case kana == 'っ':
+			double = true
+			continue
+		case kana == 'ー':
+			// The long vowel mark repeats the vowel before it
+			if len(syllables) == 0 {
+				return "", false
+			}
+			last := syllables[len(syllables)-1]
+			syllables = append(syllables, last[len(last)-1:])
+			continue
+		}
+
+		if glide, ok := kanaGlides[kana]; ok {
+			// Small "ya", "yu" and "yo" replace the vowel of the syllable before them, which ends in "i"
+			if len(syllables) == 0 || !strings.HasSuffix(syllables[len(syllables)-1], "i") {
+				return "", false
+			}
+			last := syllables[len(syllables)-1]
+			consonant := strings.TrimSuffix(last, "i")
+			if !strings.HasSuffix(consonant, "sh") && !strings.HasSuffix(consonant, "ch") && consonant != "j" {
+				consonant += "y"
+			}
+			syllables[len(syllables)-1] = consonant + glide
+			continue
+		}
+
+		if vowel, ok := kanaSmallVowels[kana]; ok && len(syllables) > 0 {
+			// Small vowels replace the vowel of the syllable before them, where it has a digraph
+			last := syllables[len(syllables)-1]
+			if consonant, ok := kanaDigraphConsonants[last]; ok {
+				syllables[len(syllables)-1] = consonant + vowel
+				continue
+			}
+		}
+
+		syllable, ok := kanaSyllables[kana]
+		if !ok {
+			return "", false
+		}
+		if double {
+			// The small "tsu" doubles the consonant after it ("tch" rather than "cch")
+			if strings.HasPrefix(syllable, "ch") {
+				syllable = "t" + syllable
+			} else {
+				syllable = syllable[:1] + syllable
+			}
+			double = false
+		}
+		syllables = append(syllables, syllable)
+	}
+	if double {
+		// A small "tsu" at the end has no consonant to double
+		return "", false
+	}
+
+	// "n" is followed by an apostrophe where it would otherwise run into a vowel or "y"
+	var result strings.Builder
+	for i, syllable := range syllables {
+		result.WriteString(syllable)
+		if syllable == "n" && i+1 < len(syllables) && strings.ContainsAny(syllables[i+1][:1], "aeiouy") {
+			result.WriteString("'")
+		}
+	}
+
+	return result.String(), true
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package transliteration
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/stuartthompson/dailyvocab/app"
+)
+
+// romanizer ...
+// Typedef for functions that romanize a word (a run of letters). Returns false if the word contains
+// letters that cannot be romanized.
+type romanizer func(word []rune) (string, bool)
+
+// romanizerFor ...
+// Gets the romanizer for a language, based on the script it is written in (or nil if there is none).
+func romanizerFor(languageCode string) romanizer {
+	language, ok := app.LookupLanguage(languageCode)
+	if !ok {
+		return nil
+	}
+
+	switch language.Script {
+	case "Grek":
+		return romanizeGreek
+	case "Cyrl":
+		if strings.HasPrefix(language.Tag, "uk") {
+			return romanizeUkrainian
+		}
+		return romanizeRussian
+	case "Jpan":
+		return romanizeKana
+	}
+
+	return nil
+}
+
+// Romanize ...
+// Transliterates text in a language into Latin letters (Greek and Cyrillic letters, and Japanese kana
+// using Hepburn romanization). Returns false if the language has no rules or the text contains letters
+// the rules do not cover (such as kanji).
+func Romanize(languageCode string, text string) (string, bool) {
+	romanize := romanizerFor(languageCode)
+	if romanize == nil {
+		return "", false
+	}
+
+	// Romanize each run of letters, keeping the characters between them
+	var result strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			result.WriteRune(runes[i])
+			i++
+			continue
+		}
+		end := i
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		romanized, ok := romanize(runes[i:end])
+		if !ok {
+			return "", false
+		}
+		result.WriteString(matchCase(runes[i:end], romanized))
+		i = end
+	}
+
+	return result.String(), true
+}
+
+// Backfill ...
+// Fills in the anglicized form of translations that lack one, where the language is expected to have one
+// and it can be romanized. Returns the number of translations filled in.
+func Backfill(words []app.Word) int {
+	filled := 0
+	for i := range words {
+		for j := range words[i].Translations {
+			if backfillTranslation(&words[i].Translations[j]) {
+				filled++
+			}
+		}
+	}
+
+	return filled
+}
+
+// backfillTranslation ...
+// Fills in the anglicized form of a translation if it lacks one and it can be romanized. Returns true if it
+// was filled in.
+func backfillTranslation(translation *app.LocalizedWord) bool {
+	if translation.Anglicized != "" || strings.TrimSpace(translation.Native) == "" {
+		return false
+	}
+	if language, ok := app.LookupLanguage(translation.LanguageCode); !ok || !language.Anglicized {
+		return false
+	}
+	romanized, ok := Romanize(translation.LanguageCode, translation.Native)
+	if !ok {
+		return false
+	}
+	translation.Anglicized = romanized
+
+	return true
+}
+
+// isWordRune ...
+// Determines whether a character is part of a word (a letter, or a mark such as a kana voicing mark).
+func isWordRune(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.Is(unicode.Mn, ch) || ch == 'ー'
+}
+
+// matchCase ...
+// Capitalizes a romanized word like the original: entirely if the original is in capitals (and longer
+// than a letter), or its first letter if the original starts with a capital.
+func matchCase(original []rune, romanized string) string {
+	upper := 0
+	for _, ch := range original {
+		if unicode.IsUpper(ch) {
+			upper++
+		}
+	}
+	switch {
+	case upper == 0 || romanized == "":
+		return romanized
+	case upper == len(original) && len(original) > 1:
+		return strings.ToUpper(romanized)
+	case unicode.IsUpper(original[0]):
+		runes := []rune(romanized)
+		return string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+
+	return romanized
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 Stuart Thompson.
+
+// This file is part of DailyVocab.
+
+// DailyVocab is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// DailyVocab is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with DailyVocab. If not, see <http://www.gnu.org/licenses/>.
+
+package transliteration
+
+import (
+	"testing"
+
+	"github.com/stuartthompson/dailyvocab/app"
+)
+
+func TestRomanize(t *testing.T) {
+	for _, test := range []struct{ language, text, expected string }{
+		{"el", "χαίρετε", "chaírete"},
+		{"el", "Μπαμπάς", "Bambás"},
+		{"el", "ευχαριστώ", "efcharistó"},
+		{"el", "αυγό", "avgó"},
+		{"el", "άγγελος", "ángelos"},
+		{"el", "ΝΤΟΜΑΤΑ", "DOMATA"},
+		{"ru", "Спасибо", "Spasibo"},
+		{"ru", "щи", "shchi"},
+		{"uk", "Київ", "Kyiv"},
+		{"uk", "гривня", "hryvnia"},
+		{"ja", "ありがとう", "arigatou"},
+		{"ja", "きょう", "kyou"},
+		{"ja", "まっちゃ", "matcha"},
+		{"ja", "きんえん", "kin'en"},
+		{"ja", "コーヒー", "koohii"},
+		{"ja", "しゃしん、じゃあね", "shashin、jaane"},
+		{"ja", "ヴァイオリン", "vaiorin"},
+		{"ja", "ヴィヴェヴォ", "vivevo"},
+		{"ja", "ファイル", "fairu"},
+		{"ja", "パーティー", "paatii"},
+		{"ja", "ディスク", "disuku"},
+		{"ja", "ウェブ", "webu"},
+		{"ja", "チェック", "chekku"},
+		{"ja", "ぁ", "a"},
+	} {
+		if romanized, ok := Romanize(test.language, test.text); !ok || romanized != test.expected {
+			t.Errorf("Expected %s %q to be romanized as %q, got %q (%v)", test.language, test.text, test.expected, romanized, ok)
+		}
+	}
+
+	// Text with letters the rules do not cover, or in languages without rules, is not romanized
+	for _, test := range []struct{ language, text string }{{"ja", "日本"}, {"ja", "あっ"}, {"ja", "かゃ"}, {"ja", "ゅう"}, {"el", "xαίρετε"}, {"fr", "bonjour"}, {"ru-Latn", "privet"}} {
+		if romanized, ok := Romanize(test.language, test.text); ok {
+			t.Errorf("Expected %s %q not to be romanized, got %q", test.language, test.text, romanized)
+		}
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	words := []app.Word{{ID: 1, Translations: []app.LocalizedWord{
+		{LanguageCode: "en-US", Native: "thank you"},
+		{LanguageCode: "el", Native: "ευχαριστώ", Anglicized: "efcharistó (manual)"},
+		{LanguageCode: "ru", Native: "спасибо"},
+		{LanguageCode: "ja", Native: "有難う"},
+	}}}
+
+	if filled := Backfill(words); filled != 1 {
+		t.Errorf("Expected 1 translation to be filled in, got %d", filled)
+	}
+	for i, expected := range []string{"", "efcharistó (manual)", "spasibo", ""} {
+		if anglicized := words[0].Translations[i].Anglicized; anglicized != expected {
+			t.Errorf("Expected translation %d to be anglicized as %q, got %q", i, expected, anglicized)
+		}
+	}
+}
+
+func TestBackfillFile(t *testing.T) {
+	content := `[
+  {
+    "id": 7,
+    "translations": [
+      { "languageCode": "en-us", "native": "yes" },
+      { "languageCode": "ru", "native": "да" },
+      { "languageCode": "el", "native": "ναι", "anglicized": "" }
+    ],
+    "type": "adverb"
+  }
+]
+`
+	expected := `[
+  {
+    "id": 7,
+    "translations": [
+      { "languageCode": "en-us", "native": "yes" },
+      { "languageCode": "ru", "native": "да", "anglicized": "da" },
+      { "languageCode": "el", "native": "ναι", "anglicized": "nai" }
+    ],
+    "type": "adverb"
+  }
+]
+`
+
+	result, romanizations, err := BackfillFile([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != expected {
+		t.Errorf("Unexpected content:\n%s", result)
+	}
+	if len(romanizations) != 2 || romanizations[0].String() != "word 7: ru да → da" || romanizations[1].String() != "word 7: el ναι → nai" {
+		t.Errorf("Unexpected romanizations %v", romanizations)
+	}
+
+	// Word lists with a header are also filled in
+	result, romanizations, err = BackfillFile([]byte(`{"header": {"tags": ["basics"]}, "words": [{"id": 8, "translations": [{"languageCode": "uk", "native": "так"}]}]}`))
+	if err != nil || string(result) != `{"header": {"tags": ["basics"]}, "words": [{"id": 8, "translations": [{"languageCode": "uk", "native": "так", "anglicized": "tak"}]}]}` || len(romanizations) != 1 {
+		t.Errorf("Unexpected content %s (%v)", result, err)
+	}
+
+	if _, _, err := BackfillFile([]byte(`"words"`)); err == nil {
+		t.Error("Expected a file that is not a list of words to be rejected")
+	}
+}